@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	_ "time/tzdata" // team SLA calendars use IANA time zones; the runtime image has none
 
 	"github.com/n1ckerr0r/pull-requests-service/internal/config"
+	"github.com/n1ckerr0r/pull-requests-service/internal/jobs"
 	"github.com/n1ckerr0r/pull-requests-service/internal/store"
 	httptr "github.com/n1ckerr0r/pull-requests-service/internal/transport/http"
 )
@@ -25,7 +28,12 @@ func main() {
 	}
 	defer st.DB().Close()
 
-	r := httptr.NewRouter(st)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go jobs.RunAbsenceReassignment(ctx, st, cfg.AbsenceJobInterval)
+	go jobs.RunReviewEscalation(ctx, st, cfg.EscalationJobInterval, cfg.EscalationMaxHops, jobs.LogNotifier{})
+
+	r := httptr.NewRouter(st, cfg.AdminToken)
 	log.Printf("listening on :%s", cfg.Port)
 	if err := r.Run(":" + cfg.Port); err != nil {
 		log.Fatalf("server failed: %v", err)
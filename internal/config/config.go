@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +12,15 @@ import (
 type Config struct {
 	DBUrl string
 	Port  string
+
+	// AdminToken authorizes admin-only requests such as force merges; when
+	// empty those requests are refused.
+	AdminToken string
+
+	AbsenceJobInterval time.Duration
+
+	EscalationJobInterval time.Duration
+	EscalationMaxHops     int
 }
 
 func Load() Config {
@@ -29,8 +40,49 @@ func Load() Config {
 		port = "8080"
 	}
 
+	adminToken := os.Getenv("ADMIN_TOKEN")
+
+	absenceInterval := time.Minute
+	if v, ok := os.LookupEnv("ABSENCE_JOB_INTERVAL"); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("invalid ABSENCE_JOB_INTERVAL: %v", err)
+		}
+		if d <= 0 {
+			log.Fatalf("invalid ABSENCE_JOB_INTERVAL: %q must be positive", v)
+		}
+		absenceInterval = d
+	}
+
+	escalationInterval := 5 * time.Minute
+	if v, ok := os.LookupEnv("ESCALATION_JOB_INTERVAL"); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("invalid ESCALATION_JOB_INTERVAL: %v", err)
+		}
+		if d <= 0 {
+			log.Fatalf("invalid ESCALATION_JOB_INTERVAL: %q must be positive", v)
+		}
+		escalationInterval = d
+	}
+	escalationHops := 2
+	if v, ok := os.LookupEnv("ESCALATION_MAX_HOPS"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("invalid ESCALATION_MAX_HOPS: %q", v)
+		}
+		escalationHops = n
+	}
+
 	return Config{
 		DBUrl: db,
 		Port:  port,
+
+		AdminToken: adminToken,
+
+		AbsenceJobInterval: absenceInterval,
+
+		EscalationJobInterval: escalationInterval,
+		EscalationMaxHops:     escalationHops,
 	}
 }
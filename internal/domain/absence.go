@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidAbsence = errors.New("invalid absence")
+
+// Absence is a period during which the user must not be picked as a reviewer.
+type Absence struct {
+	ID           int64      `db:"absence_id" json:"absence_id"`
+	UserID       UserID     `db:"user_id" json:"user_id"`
+	StartsAt     time.Time  `db:"starts_at" json:"startsAt"`
+	EndsAt       time.Time  `db:"ends_at" json:"endsAt"`
+	Reason       string     `db:"reason" json:"reason,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	ReassignedAt *time.Time `db:"reassigned_at" json:"reassignedAt,omitempty"`
+}
+
+func NewAbsence(user UserID, startsAt, endsAt time.Time, reason string) (*Absence, error) {
+	if !endsAt.After(startsAt) {
+		return nil, ErrInvalidAbsence
+	}
+	return &Absence{
+		UserID:   user,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// AssignmentEventKind is what happened to a review slot.
+type AssignmentEventKind string
+
+const (
+	EventAssigned   AssignmentEventKind = "ASSIGNED"
+	EventReassigned AssignmentEventKind = "REASSIGNED"
+	EventRemoved    AssignmentEventKind = "REMOVED"
+	// EventDeactivationReassign is a reassignment forced by the reviewer
+	// being deactivated.
+	EventDeactivationReassign AssignmentEventKind = "DEACTIVATION_REASSIGN"
+)
+
+// AssignmentEvent is one entry of a PR's assignment history. UserID is the
+// reviewer put on the slot, or taken off it for REMOVED; PreviousUserID is
+// the reviewer replaced by a reassignment. Actor is empty for changes no
+// caller identified.
+type AssignmentEvent struct {
+	ID             int64               `db:"event_id" json:"event_id"`
+	PullRequestID  string              `db:"pull_request_id" json:"pull_request_id"`
+	Kind           AssignmentEventKind `db:"kind" json:"kind"`
+	Slot           int                 `db:"slot" json:"slot"`
+	UserID         UserID              `db:"user_id" json:"user_id"`
+	PreviousUserID UserID              `db:"previous_user_id" json:"previous_user_id,omitempty"`
+	Actor          string              `db:"actor" json:"actor,omitempty"`
+	Reason         string              `db:"reason" json:"reason"`
+	CreatedAt      time.Time           `db:"created_at" json:"createdAt"`
+}
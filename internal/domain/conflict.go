@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidConflict = errors.New("invalid conflict")
+
+// Conflict marks two users who must not review each other's pull requests.
+// It is symmetric; OtherUserID is the other side as seen from UserID.
+type Conflict struct {
+	UserID      UserID    `db:"user_id" json:"user_id"`
+	OtherUserID UserID    `db:"other_user_id" json:"other_user_id"`
+	Reason      string    `db:"reason" json:"reason"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+func NewConflict(user, other UserID, reason string) (*Conflict, error) {
+	if user == "" || other == "" || user == other {
+		return nil, ErrInvalidConflict
+	}
+	return &Conflict{UserID: user, OtherUserID: other, Reason: reason}, nil
+}
+
+// Pair returns the two users in the order they are stored in.
+func (c *Conflict) Pair() (UserID, UserID) {
+	if c.UserID < c.OtherUserID {
+		return c.UserID, c.OtherUserID
+	}
+	return c.OtherUserID, c.UserID
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// EscalationState is what has been recorded about escalating the overdue
+// reviews of one review slot. UpdatedAt is zero while nothing is recorded.
+type EscalationState struct {
+	Hops         int
+	LeadNotified bool
+	UpdatedAt    time.Time
+}
+
+// EscalationStep is what to do about an overdue review.
+type EscalationStep int
+
+const (
+	// EscalationWait leaves the review alone: the lead already knows.
+	EscalationWait EscalationStep = iota
+	// EscalationReassign hands the slot to someone else, counting a hop.
+	EscalationReassign
+	// EscalationNotifyLead tells the lead; the reviewer keeps the slot.
+	EscalationNotifyLead
+)
+
+// NextEscalationStep decides what to do about a review assigned at
+// assignedAt that is overdue on a slot in the given state. A slot that
+// changed hands after the state was recorded starts over, so a reviewer seated by
+// hand or by deactivation gets the full number of hops again. The returned
+// state is the one the step builds on.
+func NextEscalationStep(state EscalationState, assignedAt time.Time, maxHops int) (EscalationStep, EscalationState) {
+	if !state.UpdatedAt.IsZero() && assignedAt.After(state.UpdatedAt) {
+		state = EscalationState{}
+	}
+	switch {
+	case state.LeadNotified:
+		return EscalationWait, state
+	case state.Hops < maxHops:
+		return EscalationReassign, state
+	default:
+		return EscalationNotifyLead, state
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "fmt"
+
+const DefaultRequiredApprovals = 1
+
+// MergePolicy describes what a PR needs before it can be merged.
+type MergePolicy struct {
+	RequiredApprovals int
+}
+
+// Evaluate checks the verdicts of the assigned reviewers, oldest first,
+// against the policy and returns a description of every rule that is not
+// met. Each reviewer counts with their latest verdict other than COMMENTED:
+// a comment neither approves nor withdraws a change request.
+func (p MergePolicy) Evaluate(reviews []Review) []string {
+	var (
+		order  []UserID
+		latest = make(map[UserID]Verdict)
+	)
+	for _, r := range reviews {
+		if r.Verdict == VerdictCommented {
+			continue
+		}
+		if _, seen := latest[r.ReviewerID]; !seen {
+			order = append(order, r.ReviewerID)
+		}
+		latest[r.ReviewerID] = r.Verdict
+	}
+
+	var (
+		approvals int
+		blocking  []UserID
+	)
+	for _, id := range order {
+		switch latest[id] {
+		case VerdictApproved:
+			approvals++
+		case VerdictChangesRequested:
+			blocking = append(blocking, id)
+		}
+	}
+
+	var unmet []string
+	if approvals < p.RequiredApprovals {
+		unmet = append(unmet, fmt.Sprintf("requires %d approvals, has %d", p.RequiredApprovals, approvals))
+	}
+	for _, id := range blocking {
+		unmet = append(unmet, fmt.Sprintf("changes requested by %s", id))
+	}
+	return unmet
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPattern = errors.New("invalid ownership pattern")
+
+// OwnershipRule is one CODEOWNERS-style line of a team: files matching the
+// pattern are owned by the listed teams and users. When several rules match
+// a path, the one added last wins, as in CODEOWNERS.
+type OwnershipRule struct {
+	ID         int64     `db:"rule_id" json:"rule_id"`
+	TeamName   TeamID    `db:"team_name" json:"team_name"`
+	Pattern    string    `db:"pattern" json:"pattern"`
+	OwnerTeams []string  `db:"-" json:"owner_teams"`
+	OwnerUsers []string  `db:"-" json:"owner_users"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+
+	// re is the compiled pattern, kept once the rule is validated or first
+	// matched so a rule is compiled once per load rather than per path
+	re *regexp.Regexp
+}
+
+func NewOwnershipRule(team TeamID, pattern string, ownerTeams, ownerUsers []string) (*OwnershipRule, error) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(ownerTeams)+len(ownerUsers) == 0 {
+		return nil, ErrInvalidPattern
+	}
+	if ownerTeams == nil {
+		ownerTeams = make([]string, 0)
+	}
+	if ownerUsers == nil {
+		ownerUsers = make([]string, 0)
+	}
+	return &OwnershipRule{
+		TeamName:   team,
+		Pattern:    pattern,
+		OwnerTeams: ownerTeams,
+		OwnerUsers: ownerUsers,
+		re:         re,
+	}, nil
+}
+
+// Matches reports whether the path is covered by the rule's pattern.
+func (r *OwnershipRule) Matches(path string) bool {
+	if r.re == nil {
+		re, err := compilePattern(r.Pattern)
+		if err != nil {
+			return false
+		}
+		r.re = re
+	}
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// PathMatch is the rule that owns a path, or nil when no rule matches.
+type PathMatch struct {
+	Path string         `json:"path"`
+	Rule *OwnershipRule `json:"rule"`
+}
+
+// Owners is the set of teams and users that own at least one changed path.
+type Owners struct {
+	Teams []string `json:"teams"`
+	Users []string `json:"users"`
+}
+
+// MatchPaths finds the owning rule of every path; rules must be in the order
+// they were added.
+func MatchPaths(rules []OwnershipRule, paths []string) ([]PathMatch, Owners) {
+	matches := make([]PathMatch, 0, len(paths))
+	owners := Owners{Teams: make([]string, 0), Users: make([]string, 0)}
+	seenTeams := make(map[string]bool)
+	seenUsers := make(map[string]bool)
+
+	for _, path := range paths {
+		m := PathMatch{Path: path}
+		for i := len(rules) - 1; i >= 0; i-- {
+			if rules[i].Matches(path) {
+				m.Rule = &rules[i]
+				break
+			}
+		}
+		if m.Rule != nil {
+			for _, t := range m.Rule.OwnerTeams {
+				if !seenTeams[t] {
+					seenTeams[t] = true
+					owners.Teams = append(owners.Teams, t)
+				}
+			}
+			for _, u := range m.Rule.OwnerUsers {
+				if !seenUsers[u] {
+					seenUsers[u] = true
+					owners.Users = append(owners.Users, u)
+				}
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches, owners
+}
+
+// compilePattern turns a CODEOWNERS pattern into a regexp. A leading slash or
+// a slash in the middle anchors the pattern to the repository root, a
+// trailing slash matches directories only, "*" and "?" stay within one path
+// segment and "**" spans segments. A pattern matching a directory also
+// matches everything below it.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	p := strings.TrimSpace(pattern)
+	if p == "" || strings.ContainsAny(p, " \t") {
+		return nil, ErrInvalidPattern
+	}
+
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		// "/" on its own owns the whole tree
+		p = "**"
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, ErrInvalidPattern
+	}
+	return re, nil
+}
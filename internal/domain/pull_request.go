@@ -1,15 +1,65 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+type PRStatus string
+
+const (
+	StatusDraft  PRStatus = "DRAFT"
+	StatusOpen   PRStatus = "OPEN"
+	StatusClosed PRStatus = "CLOSED"
+	StatusMerged PRStatus = "MERGED"
+)
+
+var (
+	ErrInvalidTransition     = errors.New("invalid status transition")
+	ErrInvalidReviewerChoice = errors.New("invalid reviewer choice")
+	ErrInvalidCoAuthors      = errors.New("invalid co-authors")
+)
+
+var transitions = map[PRStatus][]PRStatus{
+	StatusDraft:  {StatusOpen},
+	StatusOpen:   {StatusClosed, StatusMerged},
+	StatusClosed: {StatusOpen},
+}
+
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// FallbackReviewer marks an assigned reviewer drawn from one of the author
+// team's fallback teams.
+type FallbackReviewer struct {
+	UserID   UserID `json:"user_id"`
+	TeamName TeamID `json:"team_name"`
+}
 
 type PullRequest struct {
-	ID                string     `db:"pull_request_id" json:"pull_request_id"`
-	Name              string     `db:"pull_request_name" json:"pull_request_name"`
-	AuthorID          UserID     `db:"author_id" json:"author_id"`
-	Status            string     `db:"status" json:"status"` // OPEN|MERGED
-	AssignedReviewers []UserID   `json:"assigned_reviewers"`
+	ID                string             `db:"pull_request_id" json:"pull_request_id"`
+	Name              string             `db:"pull_request_name" json:"pull_request_name"`
+	AuthorID          UserID             `db:"author_id" json:"author_id"`
+	CoAuthors         []UserID           `json:"co_authors"`
+	Status            PRStatus           `db:"status" json:"status"`
+	AssignedReviewers []UserID           `json:"assigned_reviewers"`
+	FallbackReviewers []FallbackReviewer `json:"fallback_reviewers"`
+	Reviews           []Review           `json:"reviews"` // latest verdict of each assigned reviewer
+	ChangedFiles      []string           `json:"changed_files,omitempty"`
+	Labels            []string           `json:"labels"`
+	// PinnedReviewers are seated before any automatic pick; ExcludedReviewers
+	// are never picked automatically for this PR.
+	PinnedReviewers   []UserID   `json:"required_reviewers"`
+	ExcludedReviewers []UserID   `json:"excluded_reviewers"`
 	CreatedAt         time.Time  `db:"created_at" json:"createdAt"`
 	MergedAt          *time.Time `db:"merged_at" json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `db:"closed_at" json:"closedAt,omitempty"`
 }
 
 func NewPR(id, name string, author UserID) *PullRequest {
@@ -17,7 +67,51 @@ func NewPR(id, name string, author UserID) *PullRequest {
 		ID:                id,
 		Name:              name,
 		AuthorID:          author,
-		Status:            "OPEN",
+		CoAuthors:         make([]UserID, 0),
+		Status:            StatusOpen,
 		AssignedReviewers: make([]UserID, 0),
+		FallbackReviewers: make([]FallbackReviewer, 0),
+		Reviews:           make([]Review, 0),
+	}
+}
+
+func NewDraftPR(id, name string, author UserID) *PullRequest {
+	pr := NewPR(id, name, author)
+	pr.Status = StatusDraft
+	return pr
+}
+
+// Authors returns the primary author followed by the co-authors.
+func (pr *PullRequest) Authors() []UserID {
+	return append([]UserID{pr.AuthorID}, pr.CoAuthors...)
+}
+
+// SetCoAuthors records the other authors of the PR. They must be distinct
+// and must not repeat the primary author.
+func (pr *PullRequest) SetCoAuthors(ids []UserID) error {
+	seen := map[UserID]bool{pr.AuthorID: true}
+	for _, uid := range ids {
+		if uid == "" || seen[uid] {
+			return ErrInvalidCoAuthors
+		}
+		seen[uid] = true
+	}
+	pr.CoAuthors = append(make([]UserID, 0, len(ids)), ids...)
+	return nil
+}
+
+// ValidateReviewerChoice checks the reviewers the authors pinned or excluded:
+// no author can be either and nobody can be both.
+func ValidateReviewerChoice(authors []UserID, pinned, excluded []UserID) error {
+	seen := make(map[UserID]bool, len(authors)+len(pinned)+len(excluded))
+	for _, uid := range authors {
+		seen[uid] = true
+	}
+	for _, uid := range append(append(make([]UserID, 0, len(pinned)+len(excluded)), pinned...), excluded...) {
+		if uid == "" || seen[uid] {
+			return ErrInvalidReviewerChoice
+		}
+		seen[uid] = true
 	}
+	return nil
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type Verdict string
+
+const (
+	VerdictApproved         Verdict = "APPROVED"
+	VerdictChangesRequested Verdict = "CHANGES_REQUESTED"
+	VerdictCommented        Verdict = "COMMENTED"
+)
+
+var ErrInvalidVerdict = errors.New("invalid verdict")
+
+func (v Verdict) Validate() error {
+	switch v {
+	case VerdictApproved, VerdictChangesRequested, VerdictCommented:
+		return nil
+	}
+	return ErrInvalidVerdict
+}
+
+type Review struct {
+	PullRequestID string    `db:"pull_request_id" json:"-"`
+	ReviewerID    UserID    `db:"user_id" json:"user_id"`
+	Verdict       Verdict   `db:"verdict" json:"verdict"`
+	Body          string    `db:"body" json:"body,omitempty"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+}
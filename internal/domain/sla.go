@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidSLA = errors.New("invalid SLA settings")
+
+const DefaultSLAHours = 24
+
+// SLAPolicy is how much working time a team gives a reviewer. Only time
+// between BusinessStart and BusinessEnd on days outside WeekendDays, in the
+// team's Timezone, counts. Times are "HH:MM"; weekend days use
+// time.Weekday numbering (0 is Sunday).
+type SLAPolicy struct {
+	TeamName      TeamID         `json:"team_name"`
+	Hours         int            `json:"sla_hours"`
+	BusinessStart string         `json:"business_hours_start"`
+	BusinessEnd   string         `json:"business_hours_end"`
+	WeekendDays   []time.Weekday `json:"weekend_days"`
+	Timezone      string         `json:"timezone"`
+}
+
+func DefaultSLAPolicy(team TeamID) *SLAPolicy {
+	return &SLAPolicy{
+		TeamName:      team,
+		Hours:         DefaultSLAHours,
+		BusinessStart: "09:00",
+		BusinessEnd:   "18:00",
+		WeekendDays:   []time.Weekday{time.Saturday, time.Sunday},
+		Timezone:      "UTC",
+	}
+}
+
+func (p *SLAPolicy) Validate() error {
+	if p.Hours <= 0 {
+		return ErrInvalidSLA
+	}
+	start, end, err := p.window()
+	if err != nil || start >= end {
+		return ErrInvalidSLA
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return ErrInvalidSLA
+	}
+	working := 7
+	seen := make(map[time.Weekday]bool)
+	for _, d := range p.WeekendDays {
+		if d < time.Sunday || d > time.Saturday {
+			return ErrInvalidSLA
+		}
+		if !seen[d] {
+			seen[d] = true
+			working--
+		}
+	}
+	if working == 0 {
+		return ErrInvalidSLA
+	}
+	return nil
+}
+
+// DueAt returns the moment the SLA runs out for a review assigned at start.
+func (p *SLAPolicy) DueAt(start time.Time) time.Time {
+	remaining := time.Duration(p.Hours) * time.Hour
+	due := start
+	p.eachWindow(start, func(from, to time.Time) bool {
+		if span := to.Sub(from); span < remaining {
+			remaining -= span
+			return true
+		}
+		due = from.Add(remaining)
+		return false
+	})
+	return due
+}
+
+// WorkingTime returns how much working time lies between from and to.
+func (p *SLAPolicy) WorkingTime(from, to time.Time) time.Duration {
+	var total time.Duration
+	if !to.After(from) {
+		return 0
+	}
+	p.eachWindow(from, func(wFrom, wTo time.Time) bool {
+		if !wFrom.Before(to) {
+			return false
+		}
+		if wTo.After(to) {
+			wTo = to
+		}
+		total += wTo.Sub(wFrom)
+		return true
+	})
+	return total
+}
+
+// eachWindow calls fn with the working windows from start onwards, the first
+// one clipped to start, until fn returns false.
+func (p *SLAPolicy) eachWindow(start time.Time, fn func(from, to time.Time) bool) {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	open, closeAt, err := p.window()
+	if err != nil {
+		return
+	}
+	weekend := make(map[time.Weekday]bool, len(p.WeekendDays))
+	for _, d := range p.WeekendDays {
+		weekend[d] = true
+	}
+	if len(weekend) >= 7 {
+		return
+	}
+
+	// clock times are applied per day so that DST shifts do not move them
+	at := func(day time.Time, clock time.Duration) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, loc)
+	}
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for ; ; day = day.AddDate(0, 0, 1) {
+		if weekend[day.Weekday()] {
+			continue
+		}
+		from := at(day, open)
+		to := at(day, closeAt)
+		if !to.After(start) {
+			continue
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if !fn(from, to) {
+			return
+		}
+	}
+}
+
+func (p *SLAPolicy) window() (start, end time.Duration, err error) {
+	if start, err = parseClock(p.BusinessStart); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(p.BusinessEnd); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, ErrInvalidSLA
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// OverdueReview is an assignment whose reviewer has not submitted a review
+// within the SLA of the author's team.
+type OverdueReview struct {
+	PullRequestID   string        `json:"pull_request_id"`
+	PullRequestName string        `json:"pull_request_name"`
+	Slot            int           `json:"slot"`
+	ReviewerID      UserID        `json:"reviewer_id"`
+	TeamName        TeamID        `json:"team_name"`
+	AssignedAt      time.Time     `json:"assignedAt"`
+	DueAt           time.Time     `json:"dueAt"`
+	OverdueBy       time.Duration `json:"-"`
+}
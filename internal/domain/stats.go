@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidStatsRange = errors.New("invalid stats range")
+
+// StatsFilter narrows the statistics to PRs authored in one team and to a
+// time range [From, To). Empty fields do not filter.
+type StatsFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+// NewStatsFilter parses the range bounds, each either RFC 3339 or a plain
+// 2006-01-02 date. A plain To date includes that whole day.
+func NewStatsFilter(teamName, from, to string) (StatsFilter, error) {
+	f := StatsFilter{TeamName: teamName}
+	if from != "" {
+		t, _, err := parseStatsBound(from)
+		if err != nil {
+			return StatsFilter{}, err
+		}
+		f.From = &t
+	}
+	if to != "" {
+		t, dateOnly, err := parseStatsBound(to)
+		if err != nil {
+			return StatsFilter{}, err
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return StatsFilter{}, ErrInvalidStatsRange
+	}
+	return f, nil
+}
+
+func parseStatsBound(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, false, ErrInvalidStatsRange
+	}
+	return t, true, nil
+}
+
+// UserAssignments counts the review slots a user holds on open PRs and held
+// on merged ones. Closing a PR releases its slots, so closed PRs do not count.
+type UserAssignments struct {
+	UserID UserID `db:"user_id" json:"user_id"`
+	Total  int    `db:"total" json:"total"`
+	Open   int    `db:"open" json:"open"`
+	Merged int    `db:"merged" json:"merged"`
+}
+
+// AuthorPRs counts the PRs a user authored or co-authored, by status.
+type AuthorPRs struct {
+	AuthorID UserID `db:"author_id" json:"author_id"`
+	Total    int    `db:"total" json:"total"`
+	Draft    int    `db:"draft" json:"draft"`
+	Open     int    `db:"open" json:"open"`
+	Merged   int    `db:"merged" json:"merged"`
+	Closed   int    `db:"closed" json:"closed"`
+}
+
+// MergeTimeStats summarises the time from creation to merge of the PRs
+// merged in the range. The durations are nil when nothing was merged.
+type MergeTimeStats struct {
+	Merged        int      `db:"merged" json:"merged"`
+	MedianSeconds *float64 `db:"median_seconds" json:"median_seconds"`
+	AvgSeconds    *float64 `db:"avg_seconds" json:"avg_seconds"`
+	P90Seconds    *float64 `db:"p90_seconds" json:"p90_seconds"`
+}
+
+// UserReassignments counts the review slots handed away from and to a user.
+type UserReassignments struct {
+	UserID   UserID `db:"user_id" json:"user_id"`
+	Away     int    `db:"reassigned_away" json:"reassigned_away"`
+	Received int    `db:"reassigned_to" json:"reassigned_to"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+const MaxTagLength = 50
+
+// NormalizeTag turns a user skill or PR label into its stored form, so that
+// "Go" and "go " name the same skill. Skills and labels share one namespace:
+// a reviewer skilled in "sql" matches a PR labelled "sql".
+func NormalizeTag(tag string) (string, error) {
+	t := strings.ToLower(strings.TrimSpace(tag))
+	if t == "" || len(t) > MaxTagLength || strings.ContainsAny(t, " \t\n") {
+		return "", ErrInvalidTag
+	}
+	return t, nil
+}
+
+func NormalizeTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		t, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
@@ -1,9 +1,70 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRequiredReviewers = errors.New("invalid required reviewers")
+	ErrInvalidRequiredApprovals = errors.New("invalid required approvals")
+	ErrInvalidStrategy          = errors.New("invalid selection strategy")
+)
+
+// SelectionStrategy is how a team picks reviewers among its candidates.
+type SelectionStrategy string
+
+const (
+	// StrategyLeastLoaded picks the members with the fewest open reviews.
+	StrategyLeastLoaded SelectionStrategy = "LEAST_LOADED"
+	// StrategyRoundRobin takes members in turn, ignoring load.
+	StrategyRoundRobin SelectionStrategy = "ROUND_ROBIN"
+)
+
+const (
+	DefaultRequiredReviewers = 2
+	MinRequiredReviewers     = 1
+	MaxRequiredReviewers     = 10
+)
 
 type Team struct {
-	Name        string    `db:"name" json:"team_name"`
-	Description string    `db:"description,omitempty" json:"-"`
-	CreatedAt   time.Time `db:"created_at" json:"-"`
+	Name              string            `db:"name" json:"team_name"`
+	Description       string            `db:"description" json:"description"`
+	RequiredReviewers int               `db:"required_reviewers" json:"required_reviewers"`
+	RequiredApprovals int               `db:"required_approvals" json:"required_approvals"`
+	SelectionStrategy SelectionStrategy `db:"selection_strategy" json:"selection_strategy"`
+	LeadUserID        UserID            `db:"lead_user_id" json:"lead_user_id,omitempty"`
+	FallbackTeams     []string          `db:"-" json:"fallback_teams"`
+	CreatedAt         time.Time         `db:"created_at" json:"-"`
+}
+
+func NewTeam(name string) *Team {
+	return &Team{
+		Name:              name,
+		RequiredReviewers: DefaultRequiredReviewers,
+		RequiredApprovals: DefaultRequiredApprovals,
+		SelectionStrategy: StrategyLeastLoaded,
+	}
+}
+
+func ValidateRequiredReviewers(n int) error {
+	if n < MinRequiredReviewers || n > MaxRequiredReviewers {
+		return ErrInvalidRequiredReviewers
+	}
+	return nil
+}
+
+func ValidateRequiredApprovals(n int) error {
+	if n < 0 || n > MaxRequiredReviewers {
+		return ErrInvalidRequiredApprovals
+	}
+	return nil
+}
+
+func (s SelectionStrategy) Validate() error {
+	switch s {
+	case StrategyLeastLoaded, StrategyRoundRobin:
+		return nil
+	}
+	return ErrInvalidStrategy
 }
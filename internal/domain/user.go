@@ -12,6 +12,7 @@ var (
 	ErrInvalidUserID   = errors.New("invalid user ID")
 	ErrInvalidUsername = errors.New("invalid username")
 	ErrInvalidTeamID   = errors.New("invalid team ID")
+	ErrInvalidCapacity = errors.New("invalid max open reviews")
 )
 
 const (
@@ -20,12 +21,14 @@ const (
 )
 
 type User struct {
-	ID        UserID    `db:"user_id" json:"user_id"`
-	Username  string    `db:"username" json:"username"`
-	IsActive  bool      `db:"is_active" json:"is_active"`
-	TeamName  TeamID    `db:"team_name" json:"team_name"`
-	CreatedAt time.Time `db:"created_at" json:"-"`
-	UpdatedAt time.Time `db:"updated_at" json:"-"`
+	ID       UserID `db:"user_id" json:"user_id"`
+	Username string `db:"username" json:"username"`
+	IsActive bool   `db:"is_active" json:"is_active"`
+	TeamName TeamID `db:"team_name" json:"team_name"`
+	// MaxOpenReviews caps the user's OPEN review assignments; nil means no limit.
+	MaxOpenReviews *int      `db:"max_open_reviews" json:"max_open_reviews"`
+	CreatedAt      time.Time `db:"created_at" json:"-"`
+	UpdatedAt      time.Time `db:"updated_at" json:"-"`
 }
 
 func NewUser(id, username string, team TeamID, isActive bool) *User {
@@ -40,6 +43,13 @@ func NewUser(id, username string, team TeamID, isActive bool) *User {
 	}
 }
 
+func ValidateMaxOpenReviews(limit *int) error {
+	if limit != nil && *limit < 0 {
+		return ErrInvalidCapacity
+	}
+	return nil
+}
+
 func (u *User) Activate() {
 	u.IsActive = true
 	u.UpdatedAt = time.Now()
@@ -49,3 +59,12 @@ func (u *User) Deactivate() {
 	u.IsActive = false
 	u.UpdatedAt = time.Now()
 }
+
+// TeamMembership is one entry of a user's team history. FromTeam is empty
+// for the team the user was created in.
+type TeamMembership struct {
+	UserID   UserID    `db:"user_id" json:"user_id"`
+	FromTeam TeamID    `db:"from_team" json:"from_team,omitempty"`
+	ToTeam   TeamID    `db:"to_team" json:"to_team"`
+	MovedAt  time.Time `db:"moved_at" json:"movedAt"`
+}
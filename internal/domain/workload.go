@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Workload summarises a team member's review load at a point in time. A
+// pending review is an assignment on an OPEN PR the reviewer has not
+// reviewed since being assigned; any verdict ends that wait. The completed
+// counts are PRs the reviewer approved or requested changes on in the
+// window, so comments alone and repeated verdicts on one PR add nothing.
+type Workload struct {
+	UserID          UserID     `db:"user_id" json:"user_id"`
+	Username        string     `db:"username" json:"username"`
+	IsActive        bool       `db:"is_active" json:"is_active"`
+	MaxOpenReviews  *int       `db:"max_open_reviews" json:"max_open_reviews"`
+	OpenReviews     int        `db:"open_reviews" json:"open_reviews"`
+	PendingReviews  int        `db:"pending_reviews" json:"pending_reviews"`
+	OldestPendingAt *time.Time `db:"oldest_pending_at" json:"oldestPendingAt,omitempty"`
+	ReviewedLast7   int        `db:"reviewed_7d" json:"reviews_last_7_days"`
+	ReviewedLast30  int        `db:"reviewed_30d" json:"reviews_last_30_days"`
+}
+
+// OldestPendingAge is how long the oldest pending review has been waiting
+// at now, or zero when nothing is pending.
+func (w Workload) OldestPendingAge(now time.Time) time.Duration {
+	if w.OldestPendingAt == nil {
+		return 0
+	}
+	return now.Sub(*w.OldestPendingAt)
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// RunAbsenceReassignment periodically moves the open review slots of users
+// whose absence has started. It returns when ctx is cancelled.
+func RunAbsenceReassignment(ctx context.Context, st *store.Store, every time.Duration) {
+	ctx = store.WithActor(ctx, "system:absences")
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			moved, err := st.ReassignStartedAbsences(ctx)
+			if err != nil {
+				log.Printf("absence reassignment failed: %v", err)
+				continue
+			}
+			for _, r := range moved {
+				log.Printf("absence reassignment: pr %s %s -> %s (%s)", r.PullRequestID, r.OldUserID, r.NewUserID, r.Outcome())
+			}
+		}
+	}
+}
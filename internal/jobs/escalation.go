@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// Notifier tells a team lead about a PR whose review could not be moved on.
+type Notifier interface {
+	NotifyLead(ctx context.Context, e store.Escalation) error
+}
+
+// LogNotifier writes lead notifications to the log.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyLead(_ context.Context, e store.Escalation) error {
+	log.Printf("review escalation: lead %s of team %s notified about pr %s (reviewer %s, %d hops)",
+		e.LeadUserID, e.TeamName, e.PullRequestID, e.OldUserID, e.Hops)
+	return nil
+}
+
+// RunReviewEscalation periodically hands overdue reviews to other reviewers
+// and, once a slot has been passed on maxHops times, notifies the team lead
+// through n instead. Only one replica escalates per tick. It returns when ctx is
+// cancelled.
+func RunReviewEscalation(ctx context.Context, st *store.Store, every time.Duration, maxHops int, n Notifier) {
+	ctx = store.WithActor(ctx, "system:escalation")
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			escalated, ran, err := st.EscalateOverdueReviews(ctx, time.Now(), maxHops, n.NotifyLead)
+			if err != nil {
+				log.Printf("review escalation failed: %v", err)
+				continue
+			}
+			if !ran {
+				continue
+			}
+			for _, e := range escalated {
+				if !e.LeadNotified {
+					log.Printf("review escalation: pr %s %s -> %s (%s, hop %d)", e.PullRequestID, e.OldUserID, e.NewUserID, e.Outcome(), e.Hops)
+					continue
+				}
+				if e.LeadUserID == "" {
+					log.Printf("review escalation: team %s has no lead to notify about pr %s", e.TeamName, e.PullRequestID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package selection
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Candidate is a potential reviewer together with the number of OPEN pull
+// requests currently assigned to them, their limit, if any, and how many of
+// the PR's labels they have as skills.
+type Candidate struct {
+	UserID         string `db:"user_id"`
+	OpenReviews    int    `db:"open_reviews"`
+	MaxOpenReviews *int   `db:"max_open_reviews"`
+	SkillMatches   int    `db:"skill_matches"`
+}
+
+func (c Candidate) Saturated() bool {
+	return c.MaxOpenReviews != nil && c.OpenReviews >= *c.MaxOpenReviews
+}
+
+// Available drops the candidates who are at capacity and reports how many
+// were dropped.
+func Available(candidates []Candidate) (free []Candidate, saturated int) {
+	free = make([]Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Saturated() {
+			saturated++
+			continue
+		}
+		free = append(free, c)
+	}
+	return free, saturated
+}
+
+// PickSkilled lets the strategy choose among the candidates whose skills
+// overlap the PR's labels first and fills the remaining slots from the rest.
+func PickSkilled(s Strategy, candidates []Candidate, k int) []string {
+	skilled := make([]Candidate, 0, len(candidates))
+	rest := make([]Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.SkillMatches > 0 {
+			skilled = append(skilled, c)
+		} else {
+			rest = append(rest, c)
+		}
+	}
+
+	picked := s.Pick(skilled, k)
+	if len(picked) < k {
+		picked = append(picked, s.Pick(rest, k-len(picked))...)
+	}
+	return picked
+}
+
+// Strategy decides which of the candidates should review a pull request.
+// Implementations must return at most k distinct user IDs.
+type Strategy interface {
+	Pick(candidates []Candidate, k int) []string
+}
+
+// LeastLoaded picks the candidates with the fewest open assignments,
+// breaking ties randomly.
+type LeastLoaded struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewLeastLoaded(seed int64) *LeastLoaded {
+	return &LeastLoaded{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (l *LeastLoaded) Pick(candidates []Candidate, k int) []string {
+	if len(candidates) == 0 || k <= 0 {
+		return nil
+	}
+
+	pool := make([]Candidate, len(candidates))
+	copy(pool, candidates)
+
+	// sort by user id first so that the result only depends on the seed,
+	// not on the order rows came back from the database
+	sort.Slice(pool, func(i, j int) bool { return pool[i].UserID < pool[j].UserID })
+
+	l.mu.Lock()
+	l.rnd.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	l.mu.Unlock()
+
+	sort.SliceStable(pool, func(i, j int) bool { return pool[i].OpenReviews < pool[j].OpenReviews })
+
+	if len(pool) > k {
+		pool = pool[:k]
+	}
+	picked := make([]string, 0, len(pool))
+	for _, c := range pool {
+		picked = append(picked, c.UserID)
+	}
+	return picked
+}
+
+// RoundRobin takes candidates in user id order, starting after the last
+// user picked and wrapping around. Load is ignored. The cursor is kept by the
+// caller, so the strategy itself holds no state between PRs. Through
+// PickSkilled the skilled candidates are taken in turn before the rest.
+type RoundRobin struct {
+	Last string
+}
+
+func NewRoundRobin(last string) *RoundRobin {
+	return &RoundRobin{Last: last}
+}
+
+func (r *RoundRobin) Pick(candidates []Candidate, k int) []string {
+	if len(candidates) == 0 || k <= 0 {
+		return nil
+	}
+
+	pool := make([]Candidate, len(candidates))
+	copy(pool, candidates)
+	sort.Slice(pool, func(i, j int) bool { return pool[i].UserID < pool[j].UserID })
+
+	start := sort.Search(len(pool), func(i int) bool { return pool[i].UserID > r.Last })
+	if k > len(pool) {
+		k = len(pool)
+	}
+	picked := make([]string, 0, k)
+	for i := 0; i < k; i++ {
+		picked = append(picked, pool[(start+i)%len(pool)].UserID)
+	}
+	return picked
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func (s *Store) CreateAbsence(ctx context.Context, a *domain.Absence) (*domain.Absence, error) {
+	var saved domain.Absence
+	err := s.db.GetContext(ctx, &saved, `
+        INSERT INTO user_absences (user_id, starts_at, ends_at, reason, created_at)
+        SELECT user_id, $2, $3, $4, now() FROM users WHERE user_id = $1
+        RETURNING absence_id, user_id, starts_at, ends_at, reason, created_at, reassigned_at
+`, a.UserID, a.StartsAt, a.EndsAt, a.Reason)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (s *Store) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	absences := make([]domain.Absence, 0)
+	err := s.db.SelectContext(ctx, &absences, `
+        SELECT absence_id, user_id, starts_at, ends_at, reason, created_at, reassigned_at
+        FROM user_absences WHERE user_id = $1
+        ORDER BY starts_at
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return absences, nil
+}
+
+func (s *Store) DeleteAbsence(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM user_absences WHERE absence_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ReassignStartedAbsences hands the OPEN review slots of every user whose
+// absence has started to other candidates. Each slot is tried once per
+// absence: one nobody could take is recorded and left to escalation rather
+// than tried again on every run. An absence is marked handled once none of
+// the user's OPEN slots remain; SKIP LOCKED lets several replicas run the job
+// side by side.
+func (s *Store) ReassignStartedAbsences(ctx context.Context) ([]Reassignment, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in ReassignStartedAbsences: %v", rollbackErr)
+		}
+	}()
+
+	var started []domain.Absence
+	if err := tx.SelectContext(ctx, &started, `
+        SELECT absence_id, user_id, starts_at, ends_at, reason, created_at, reassigned_at
+        FROM user_absences
+        WHERE reassigned_at IS NULL AND starts_at <= now() AND ends_at > now()
+        ORDER BY starts_at
+        FOR UPDATE SKIP LOCKED
+`); err != nil {
+		return nil, err
+	}
+
+	result := make([]Reassignment, 0)
+	for _, a := range started {
+		var prIDs []string
+		if err := tx.SelectContext(ctx, &prIDs, `
+            SELECT a.pull_request_id FROM pr_assignments a
+            JOIN prs p ON p.pull_request_id = a.pull_request_id
+            WHERE a.user_id = $1 AND p.status = $2
+              AND NOT EXISTS (
+                  SELECT 1 FROM user_absence_attempts t
+                  WHERE t.absence_id = $3 AND t.pull_request_id = a.pull_request_id
+              )
+            ORDER BY a.pull_request_id
+`, a.UserID, domain.StatusOpen, a.ID); err != nil {
+			return nil, err
+		}
+		moved, err := s.reassignSlots(ctx, tx, string(a.UserID), prIDs, eventCause{Kind: domain.EventReassigned, Reason: reasonAbsent})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range moved {
+			if !r.NoCandidate {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO user_absence_attempts (absence_id, pull_request_id, attempted_at) VALUES ($1,$2,now())`,
+				a.ID, r.PullRequestID); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, moved...)
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE user_absences SET reassigned_at = now()
+            WHERE absence_id = $1 AND NOT EXISTS (
+                SELECT 1 FROM pr_assignments a JOIN prs p ON p.pull_request_id = a.pull_request_id
+                WHERE a.user_id = $2 AND p.status = $3
+            )
+`, a.ID, a.UserID, domain.StatusOpen); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// saveAuthors records every author of a new PR. Co-authors must exist.
+func saveAuthors(ctx context.Context, tx *sqlx.Tx, pr *domain.PullRequest) error {
+	if len(pr.CoAuthors) > 0 {
+		ids := make([]string, 0, len(pr.CoAuthors))
+		for _, uid := range pr.CoAuthors {
+			ids = append(ids, string(uid))
+		}
+		var n int
+		if err := tx.GetContext(ctx, &n, `SELECT COUNT(*) FROM users WHERE user_id = ANY($1)`, pq.Array(ids)); err != nil {
+			return err
+		}
+		if n != len(ids) {
+			return ErrNotFound
+		}
+	}
+
+	for i, uid := range pr.Authors() {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_authors (pull_request_id, user_id, is_primary) VALUES ($1,$2,$3)`,
+			pr.ID, uid, i == 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prAuthors returns the primary author and the co-authors of the PR.
+func prAuthors(ctx context.Context, q sqlx.QueryerContext, prID string) ([]string, error) {
+	authors := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, q, &authors, `
+        SELECT user_id FROM pr_authors WHERE pull_request_id = $1
+        ORDER BY is_primary DESC, user_id
+`, prID); err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+func loadCoAuthors(ctx context.Context, q sqlx.QueryerContext, pr *domain.PullRequest) error {
+	pr.CoAuthors = make([]domain.UserID, 0)
+	return sqlx.SelectContext(ctx, q, &pr.CoAuthors, `
+        SELECT user_id FROM pr_authors WHERE pull_request_id = $1 AND NOT is_primary
+        ORDER BY user_id
+`, pr.ID)
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/selection"
+)
+
+var ErrInvalidCandidate = errors.New("invalid candidate")
+
+// Checks a chosen replacement can fail, reported in InvalidCandidateError.
+const (
+	CandidateNotInTeam       = "NOT_IN_TEAM"
+	CandidateInactive        = "INACTIVE"
+	CandidateAbsent          = "ABSENT"
+	CandidateAtCapacity      = "AT_CAPACITY"
+	CandidateAlreadyAssigned = "ALREADY_ASSIGNED"
+	CandidateIsAuthor        = "IS_AUTHOR"
+	CandidateExcluded        = "EXCLUDED"
+	CandidateConflict        = "CONFLICT_OF_INTEREST"
+	CandidateNotOwnerTeam    = "NOT_OWNER_TEAM"
+)
+
+// InvalidCandidateError names the check a chosen replacement reviewer failed.
+type InvalidCandidateError struct {
+	Check string
+}
+
+func (e *InvalidCandidateError) Error() string {
+	return ErrInvalidCandidate.Error() + ": " + e.Check
+}
+
+func (e *InvalidCandidateError) Unwrap() error {
+	return ErrInvalidCandidate
+}
+
+// validateChosen checks a hand-picked replacement for a slot: they must be in
+// the author's team or one of its fallback teams, or, when the slot covers an
+// owning team or user, in the team the owner is drawn from; be active, not
+// absent, below their review limit and not review the PR yet, and be neither
+// one of its authors, nor excluded by them, nor in conflict with any of them.
+func validateChosen(ctx context.Context, tx *sqlx.Tx, prID, teamName string, owner slotOwner, userID string) (pick, error) {
+	var u struct {
+		TeamName   string `db:"team_name"`
+		IsActive   bool   `db:"is_active"`
+		Absent     bool   `db:"absent"`
+		Open       int    `db:"open_reviews"`
+		Max        *int   `db:"max_open_reviews"`
+		IsAuthor   bool   `db:"is_author"`
+		Assigned   bool   `db:"assigned"`
+		Excluded   bool   `db:"excluded"`
+		Conflicted bool   `db:"conflicted"`
+	}
+	if err := tx.GetContext(ctx, &u, `
+        SELECT u.team_name, u.is_active, u.max_open_reviews,
+            EXISTS (SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND ab.starts_at <= now() AND ab.ends_at > now()) AS absent,
+            (SELECT COUNT(*) FROM pr_assignments oa JOIN prs op ON op.pull_request_id = oa.pull_request_id
+             WHERE oa.user_id = u.user_id AND op.status = $4) AS open_reviews,
+            EXISTS (SELECT 1 FROM pr_authors pa WHERE pa.pull_request_id = p.pull_request_id AND pa.user_id = u.user_id) AS is_author,
+            EXISTS (SELECT 1 FROM pr_assignments a WHERE a.pull_request_id = p.pull_request_id AND a.user_id = u.user_id) AS assigned,
+            EXISTS (SELECT 1 FROM pr_reviewer_preferences pref
+                    WHERE pref.pull_request_id = p.pull_request_id AND pref.user_id = u.user_id AND pref.kind = $3) AS excluded,
+            EXISTS (SELECT 1 FROM user_conflicts c JOIN pr_authors pa ON pa.user_id IN (c.user_a, c.user_b)
+                    WHERE pa.pull_request_id = p.pull_request_id AND u.user_id IN (c.user_a, c.user_b) AND u.user_id <> pa.user_id) AS conflicted
+        FROM users u, prs p
+        WHERE u.user_id = $1 AND p.pull_request_id = $2
+`, userID, prID, preferenceExcluded, domain.StatusOpen); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pick{}, ErrNotFound
+		}
+		return pick{}, err
+	}
+
+	switch {
+	case u.IsAuthor:
+		return pick{}, &InvalidCandidateError{Check: CandidateIsAuthor}
+	case u.Assigned:
+		return pick{}, &InvalidCandidateError{Check: CandidateAlreadyAssigned}
+	case !u.IsActive:
+		return pick{}, &InvalidCandidateError{Check: CandidateInactive}
+	case u.Absent:
+		return pick{}, &InvalidCandidateError{Check: CandidateAbsent}
+	case selection.Candidate{OpenReviews: u.Open, MaxOpenReviews: u.Max}.Saturated():
+		return pick{}, &InvalidCandidateError{Check: CandidateAtCapacity}
+	case u.Excluded:
+		return pick{}, &InvalidCandidateError{Check: CandidateExcluded}
+	case u.Conflicted:
+		return pick{}, &InvalidCandidateError{Check: CandidateConflict}
+	}
+
+	p := pick{UserID: userID}
+	if owner.covered() {
+		// taking the seat from anyone else would drop the owner's coverage
+		if u.TeamName != owner.pool() {
+			return pick{}, &InvalidCandidateError{Check: CandidateNotOwnerTeam}
+		}
+		return owner.mark(p), nil
+	}
+	switch u.TeamName {
+	case teamName:
+	default:
+		fallbacks, err := teamFallbacks(ctx, tx, teamName)
+		if err != nil {
+			return pick{}, err
+		}
+		allowed := false
+		for _, fb := range fallbacks {
+			if fb == u.TeamName {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return pick{}, &InvalidCandidateError{Check: CandidateNotInTeam}
+		}
+		p.FallbackTeam = u.TeamName
+	}
+	return p, nil
+}
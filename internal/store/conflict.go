@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+var ErrConflictOfInterest = errors.New("conflict of interest")
+
+func (s *Store) CreateConflict(ctx context.Context, c *domain.Conflict) (*domain.Conflict, error) {
+	a, b := c.Pair()
+	var n int
+	if err := s.db.GetContext(ctx, &n, `SELECT COUNT(*) FROM users WHERE user_id IN ($1, $2)`, a, b); err != nil {
+		return nil, err
+	}
+	if n != 2 {
+		return nil, ErrNotFound
+	}
+
+	saved := *c
+	err := s.db.GetContext(ctx, &saved.CreatedAt, `
+        INSERT INTO user_conflicts (user_a, user_b, reason, created_at) VALUES ($1,$2,$3,now())
+        ON CONFLICT (user_a, user_b) DO NOTHING
+        RETURNING created_at
+`, a, b, c.Reason)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (s *Store) DeleteConflict(ctx context.Context, c *domain.Conflict) error {
+	a, b := c.Pair()
+	res, err := s.db.ExecContext(ctx, `DELETE FROM user_conflicts WHERE user_a = $1 AND user_b = $2`, a, b)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) ListConflicts(ctx context.Context, userID string) ([]domain.Conflict, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	conflicts := make([]domain.Conflict, 0)
+	err := s.db.SelectContext(ctx, &conflicts, `
+        SELECT $1::text AS user_id, CASE WHEN user_a = $1 THEN user_b ELSE user_a END AS other_user_id, reason, created_at
+        FROM user_conflicts WHERE user_a = $1 OR user_b = $1
+        ORDER BY other_user_id
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// authorConflicts returns the users who have a conflict with any of the PR's
+// authors and so may not review it.
+func authorConflicts(ctx context.Context, q sqlx.QueryerContext, prID string) ([]string, error) {
+	conflicted := make([]string, 0)
+	err := sqlx.SelectContext(ctx, q, &conflicted, `
+        SELECT DISTINCT CASE WHEN c.user_a = pa.user_id THEN c.user_b ELSE c.user_a END
+        FROM user_conflicts c
+        JOIN pr_authors pa ON pa.user_id IN (c.user_a, c.user_b)
+        WHERE pa.pull_request_id = $1
+`, prID)
+	if err != nil {
+		return nil, err
+	}
+	return conflicted, nil
+}
@@ -0,0 +1,362 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/selection"
+)
+
+var ErrUserNotInTeam = errors.New("user not in team")
+
+const (
+	OutcomeReassigned  = "REASSIGNED"
+	OutcomeNoCandidate = "NO_CANDIDATE"
+	OutcomeCapacity    = "CAPACITY_EXHAUSTED"
+	OutcomeReleased    = "RELEASED"
+)
+
+func (r Reassignment) Outcome() string {
+	switch {
+	case r.CapacityExhausted:
+		return OutcomeCapacity
+	case r.NoCandidate:
+		return OutcomeNoCandidate
+	case r.NewUserID == "":
+		return OutcomeReleased
+	default:
+		return OutcomeReassigned
+	}
+}
+
+type openSlot struct {
+	PullRequestID     string `db:"pull_request_id"`
+	UserID            string `db:"user_id"`
+	Slot              int    `db:"slot"`
+	AuthorID          string `db:"author_id"`
+	AuthorTeam        string `db:"author_team"`
+	RequiredReviewers int    `db:"required_reviewers"`
+	slotOwner
+}
+
+// DeactivateTeamUsers deactivates the given members of the team and hands
+// their OPEN review slots on the way the PRs were staffed: to the remaining
+// active members of each PR author's team, falling back to that team's
+// fallback teams, and for a seat covering a file owner to the owner's team.
+// A replacement therefore need not be a member of the deactivated team: a
+// reviewer borrowed from it is replaced from the author's own team first.
+// Everything happens in one transaction. Candidate load is read once per
+// team and tracked in memory so the cost does not grow with a query per PR.
+func (s *Store) DeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) ([]Reassignment, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in DeactivateTeamUsers: %v", rollbackErr)
+		}
+	}()
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, teamName); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	var members []string
+	if err := tx.SelectContext(ctx, &members, `
+        SELECT user_id FROM users WHERE team_name = $1 AND user_id = ANY($2)
+        ORDER BY user_id FOR UPDATE
+`, teamName, pq.Array(userIDs)); err != nil {
+		return nil, err
+	}
+	if len(members) != len(uniqueStrings(userIDs)) {
+		return nil, ErrUserNotInTeam
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET is_active = false, updated_at = now() WHERE user_id = ANY($1)`, pq.Array(members)); err != nil {
+		return nil, err
+	}
+
+	var slots []openSlot
+	if err := tx.SelectContext(ctx, &slots, `
+        SELECT a.pull_request_id, a.user_id, a.slot, p.author_id, au.team_name AS author_team, t.required_reviewers,
+            COALESCE(a.owner_team, '') AS owner_team, COALESCE(a.owner_user, '') AS owner_user,
+            COALESCE(ou.team_name, '') AS owner_user_team
+        FROM pr_assignments a
+        JOIN prs p ON p.pull_request_id = a.pull_request_id
+        JOIN users au ON au.user_id = p.author_id
+        JOIN teams t ON t.name = au.team_name
+        LEFT JOIN users ou ON ou.user_id = a.owner_user
+        WHERE a.user_id = ANY($1) AND p.status = $2
+        ORDER BY a.pull_request_id, a.slot
+        FOR UPDATE OF p, a
+`, pq.Array(members), domain.StatusOpen); err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 {
+		return make([]Reassignment, 0), tx.Commit()
+	}
+
+	prIDs := make([]string, 0, len(slots))
+	for _, sl := range slots {
+		prIDs = append(prIDs, sl.PullRequestID)
+	}
+	var assigned []struct {
+		PullRequestID string `db:"pull_request_id"`
+		UserID        string `db:"user_id"`
+	}
+	// the authors and the users they excluded or are in conflict with count
+	// as already on the PR, so they are never picked for it
+	if err := tx.SelectContext(ctx, &assigned, `
+        SELECT pull_request_id, user_id FROM pr_assignments WHERE pull_request_id = ANY($1)
+        UNION SELECT pull_request_id, user_id FROM pr_reviewer_preferences WHERE pull_request_id = ANY($1) AND kind = $2
+        UNION SELECT pull_request_id, user_id FROM pr_authors WHERE pull_request_id = ANY($1)
+        UNION SELECT pa.pull_request_id, CASE WHEN c.user_a = pa.user_id THEN c.user_b ELSE c.user_a END
+            FROM pr_authors pa JOIN user_conflicts c ON pa.user_id IN (c.user_a, c.user_b)
+            WHERE pa.pull_request_id = ANY($1)
+`, pq.Array(uniqueStrings(prIDs)), preferenceExcluded); err != nil {
+		return nil, err
+	}
+	onPR := make(map[string]map[string]bool)
+	for _, a := range assigned {
+		if onPR[a.PullRequestID] == nil {
+			onPR[a.PullRequestID] = make(map[string]bool)
+		}
+		onPR[a.PullRequestID][a.UserID] = true
+	}
+
+	// replacements come from the author's team and its fallbacks, as when
+	// the PR was staffed; each author team's chain is read once
+	chains := make(map[string][]string)
+	chainOf := func(authorTeam string) ([]string, error) {
+		if chain, ok := chains[authorTeam]; ok {
+			return chain, nil
+		}
+		fallbacks, err := teamFallbacks(ctx, tx, authorTeam)
+		if err != nil {
+			return nil, err
+		}
+		chains[authorTeam] = append([]string{authorTeam}, fallbacks...)
+		return chains[authorTeam], nil
+	}
+
+	// the cursors of every team a slot may be offered to are locked up front
+	// in a fixed order; picks made below move them in memory and they are
+	// written back at the end
+	var teams []string
+	for _, sl := range slots {
+		chain, err := chainOf(sl.AuthorTeam)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(append(teams, chain...), sl.pool())
+	}
+	cursors, err := lockRotations(ctx, tx, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	// one load query per team, read the first time the team is needed and
+	// before it gives anyone a slot; picks made below are tracked in memory
+	pools := make(map[string][]selection.Candidate)
+	loadPool := func(team string) error {
+		if _, ok := pools[team]; ok {
+			return nil
+		}
+		pool, err := candidatePool(ctx, tx, team, nil, nil)
+		if err != nil {
+			return err
+		}
+		pools[team] = pool
+		return nil
+	}
+	advanced := make(map[string]bool)
+
+	// skill matches depend on the PR, so skills and labels are read once
+	// and matched in memory
+	var skillRows []struct {
+		UserID string `db:"user_id"`
+		Skill  string `db:"skill"`
+	}
+	if err := tx.SelectContext(ctx, &skillRows, `
+        SELECT user_id, skill FROM user_skills
+        WHERE skill IN (SELECT label FROM pr_labels WHERE pull_request_id = ANY($1))
+`, pq.Array(uniqueStrings(prIDs))); err != nil {
+		return nil, err
+	}
+	skills := make(map[string]map[string]bool)
+	for _, r := range skillRows {
+		if skills[r.UserID] == nil {
+			skills[r.UserID] = make(map[string]bool)
+		}
+		skills[r.UserID][r.Skill] = true
+	}
+	var labelRows []struct {
+		PullRequestID string `db:"pull_request_id"`
+		Label         string `db:"label"`
+	}
+	if err := tx.SelectContext(ctx, &labelRows, `SELECT pull_request_id, label FROM pr_labels WHERE pull_request_id = ANY($1)`,
+		pq.Array(uniqueStrings(prIDs))); err != nil {
+		return nil, err
+	}
+	labels := make(map[string][]string)
+	for _, r := range labelRows {
+		labels[r.PullRequestID] = append(labels[r.PullRequestID], r.Label)
+	}
+
+	var (
+		result                                               = make([]Reassignment, 0, len(slots))
+		updPRs, updOlds, updUsers, updFallbacks, releasedPRs []string
+		updOwnerTeams, updOwnerUsers                         []string
+		updSlots, releasedSlots                              []int64
+	)
+	for _, sl := range slots {
+		r := Reassignment{PullRequestID: sl.PullRequestID, OldUserID: sl.UserID}
+		if sl.Slot > sl.RequiredReviewers && !sl.covered() {
+			releasedPRs = append(releasedPRs, sl.PullRequestID)
+			releasedSlots = append(releasedSlots, int64(sl.Slot))
+			result = append(result, r)
+			continue
+		}
+
+		// a slot covering an owning team or user is offered to that team first
+		chain, err := chainOf(sl.AuthorTeam)
+		if err != nil {
+			return nil, err
+		}
+		if pool := sl.pool(); pool != "" {
+			chain = append([]string{pool}, chain...)
+		}
+		for _, team := range chain {
+			if err := loadPool(team); err != nil {
+				return nil, err
+			}
+		}
+
+		var (
+			fromTeam  string
+			saturated int
+		)
+		for _, team := range chain {
+			pool := pools[team]
+			candidates := make([]selection.Candidate, 0, len(pool))
+			for _, c := range pool {
+				if c.UserID != sl.AuthorID && !onPR[sl.PullRequestID][c.UserID] {
+					c.SkillMatches = 0
+					for _, l := range labels[sl.PullRequestID] {
+						if skills[c.UserID][l] {
+							c.SkillMatches++
+						}
+					}
+					candidates = append(candidates, c)
+				}
+			}
+			free, n := selection.Available(candidates)
+			saturated += n
+			var picked []string
+			if last, rotating := cursors[team]; rotating {
+				picked = selection.PickSkilled(selection.NewRoundRobin(last), free, 1)
+			} else {
+				picked = selection.PickSkilled(s.strategy, free, 1)
+			}
+			if len(picked) > 0 {
+				r.NewUserID, fromTeam = picked[0], team
+				if _, rotating := cursors[team]; rotating {
+					cursors[team], advanced[team] = picked[0], true
+				}
+				break
+			}
+		}
+		if r.NewUserID == "" {
+			r.NoCandidate = true
+			r.CapacityExhausted = saturated > 0
+			result = append(result, r)
+			continue
+		}
+
+		onPR[sl.PullRequestID][r.NewUserID] = true
+		for i := range pools[fromTeam] {
+			if pools[fromTeam][i].UserID == r.NewUserID {
+				pools[fromTeam][i].OpenReviews++
+			}
+		}
+		// the same markers reassign sets: an owner seat when the owner's team
+		// took it, a fallback seat whenever the reviewer is from outside the
+		// author's team
+		var p pick
+		if sl.covered() && fromTeam == sl.pool() {
+			p = sl.mark(p)
+		} else if fromTeam != sl.AuthorTeam {
+			p.FallbackTeam = fromTeam
+		}
+		updPRs = append(updPRs, sl.PullRequestID)
+		updSlots = append(updSlots, int64(sl.Slot))
+		updOlds = append(updOlds, sl.UserID)
+		updUsers = append(updUsers, r.NewUserID)
+		updFallbacks = append(updFallbacks, p.FallbackTeam)
+		updOwnerTeams = append(updOwnerTeams, p.OwnerTeam)
+		updOwnerUsers = append(updOwnerUsers, p.OwnerUser)
+		result = append(result, r)
+	}
+
+	if len(releasedPRs) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+            WITH released AS (
+                DELETE FROM pr_assignments a
+                USING unnest($1::text[], $2::smallint[]) AS v(pull_request_id, slot)
+                WHERE a.pull_request_id = v.pull_request_id AND a.slot = v.slot
+                RETURNING a.pull_request_id, a.slot, a.user_id
+            )
+            INSERT INTO assignment_events (pull_request_id, kind, slot, user_id, actor, reason, created_at)
+            SELECT pull_request_id, $3::text, slot, user_id, NULLIF($4::text, ''), $5::text, now() FROM released
+`, pq.Array(releasedPRs), pq.Array(releasedSlots), domain.EventRemoved, actorFrom(ctx), reasonSlotReleased); err != nil {
+			return nil, err
+		}
+	}
+	if len(updPRs) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE pr_assignments a SET user_id = v.user_id, fallback_team = NULLIF(v.fallback_team, ''),
+                owner_team = NULLIF(v.owner_team, ''), owner_user = NULLIF(v.owner_user, ''), assigned_at = now()
+            FROM unnest($1::text[], $2::smallint[], $3::text[], $4::text[], $5::text[], $6::text[])
+                AS v(pull_request_id, slot, user_id, fallback_team, owner_team, owner_user)
+            WHERE a.pull_request_id = v.pull_request_id AND a.slot = v.slot
+`, pq.Array(updPRs), pq.Array(updSlots), pq.Array(updUsers), pq.Array(updFallbacks), pq.Array(updOwnerTeams), pq.Array(updOwnerUsers)); err != nil {
+			return nil, err
+		}
+		if err := logReassignments(ctx, tx, eventCause{Kind: domain.EventDeactivationReassign, Reason: reasonDeactivated},
+			updPRs, updSlots, updOlds, updUsers); err != nil {
+			return nil, err
+		}
+	}
+
+	for team := range advanced {
+		if err := advanceRotation(ctx, tx, team, cursors[team]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func uniqueStrings(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
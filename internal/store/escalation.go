@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// escalationLockKey is the advisory lock held by the replica running an
+// escalation pass; the others skip the tick instead of waiting for it.
+const escalationLockKey int64 = 0x70725f657363 // "pr_esc"
+
+// Escalation is the outcome of one overdue review. Either the slot was handed
+// to someone else, counting a hop for the slot, or the lead of the author's
+// team was notified: the slot used up its hops or nobody else could take it.
+// LeadUserID is empty when the team has no lead.
+type Escalation struct {
+	Reassignment
+	Slot         int    `json:"slot"`
+	TeamName     string `json:"team_name"`
+	Hops         int    `json:"hops"`
+	LeadNotified bool   `json:"lead_notified,omitempty"`
+	LeadUserID   string `json:"lead_user_id,omitempty"`
+}
+
+// LeadNotifier tells a team lead about an escalation. The escalation is only
+// recorded once it returns nil, so a failed notification is tried again on
+// the next pass.
+type LeadNotifier func(ctx context.Context, e Escalation) error
+
+// EscalateOverdueReviews reassigns every review past its SLA by now. A slot
+// is passed on at most maxHops times; after that, or when no candidate is
+// left, the team lead is notified through notify once and the reviewer keeps
+// the slot. Each review is escalated in its own short transaction while the
+// escalation lock is held for the whole pass; a review that fails is logged
+// and skipped. ran is false when another replica holds the lock.
+func (s *Store) EscalateOverdueReviews(ctx context.Context, now time.Time, maxHops int, notify LeadNotifier) (escalations []Escalation, ran bool, err error) {
+	conn, err := s.db.Connx(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.GetContext(ctx, &locked, `SELECT pg_try_advisory_lock($1)`, escalationLockKey); err != nil {
+		return nil, false, err
+	}
+	if !locked {
+		return nil, false, nil
+	}
+	defer func() {
+		// the lock belongs to the session, which goes back to the pool, so it
+		// is released even when ctx is done
+		if _, unlockErr := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, escalationLockKey); unlockErr != nil {
+			log.Printf("warning: advisory unlock failed in EscalateOverdueReviews: %v", unlockErr)
+		}
+	}()
+
+	overdue, err := overdueReviews(ctx, conn, "", now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := make([]Escalation, 0, len(overdue))
+	for _, o := range overdue {
+		e, ok, err := s.escalate(ctx, conn, o, maxHops, notify)
+		if err != nil {
+			log.Printf("review escalation of pr %s (reviewer %s) failed: %v", o.PullRequestID, o.ReviewerID, err)
+			continue
+		}
+		if ok {
+			result = append(result, e)
+		}
+	}
+	return result, true, nil
+}
+
+// escalate takes the next escalation step for one overdue review. ok is
+// false when nothing was done.
+func (s *Store) escalate(ctx context.Context, conn *sqlx.Conn, o domain.OverdueReview, maxHops int, notify LeadNotifier) (e Escalation, ok bool, err error) {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return Escalation{}, false, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in escalate: %v", rollbackErr)
+		}
+	}()
+
+	var row struct {
+		Hops           int          `db:"hops"`
+		LeadNotifiedAt sql.NullTime `db:"lead_notified_at"`
+		UpdatedAt      sql.NullTime `db:"updated_at"`
+	}
+	if err := tx.GetContext(ctx, &row, `
+        SELECT hops, lead_notified_at, updated_at FROM pr_escalations
+        WHERE pull_request_id = $1 AND slot = $2 FOR UPDATE
+`, o.PullRequestID, o.Slot); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Escalation{}, false, err
+	}
+	step, state := domain.NextEscalationStep(domain.EscalationState{
+		Hops:         row.Hops,
+		LeadNotified: row.LeadNotifiedAt.Valid,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}, o.AssignedAt, maxHops)
+	if step == domain.EscalationWait {
+		return Escalation{}, false, nil
+	}
+
+	e = Escalation{
+		Reassignment: Reassignment{PullRequestID: o.PullRequestID, OldUserID: string(o.ReviewerID)},
+		Slot:         o.Slot,
+		TeamName:     string(o.TeamName),
+		Hops:         state.Hops,
+	}
+	if step == domain.EscalationReassign {
+		candidate, err := s.reassign(ctx, tx, o.PullRequestID, string(o.ReviewerID), "", eventCause{Kind: domain.EventReassigned, Reason: reasonReviewEscalation})
+		switch {
+		case errors.Is(err, ErrReviewerNotAssigned), errors.Is(err, ErrPRNotOpen), errors.Is(err, ErrPRMerged):
+			// changed since the overdue list was read
+			return Escalation{}, false, nil
+		case errors.Is(err, ErrNoCandidate):
+			e.NoCandidate = true
+		case errors.Is(err, ErrCapacityExhausted):
+			e.NoCandidate, e.CapacityExhausted = true, true
+		case err != nil:
+			return Escalation{}, false, err
+		case candidate == "":
+			// the team needs fewer reviewers now and the slot was released
+			return e, true, tx.Commit()
+		default:
+			e.NewUserID = candidate
+			e.Hops++
+		}
+	}
+	if e.NewUserID == "" {
+		e.LeadNotified = true
+		if err := tx.GetContext(ctx, &e.LeadUserID, `SELECT COALESCE(lead_user_id, '') FROM teams WHERE name = $1`, e.TeamName); err != nil {
+			return Escalation{}, false, err
+		}
+		if e.LeadUserID != "" {
+			if err := notify(ctx, e); err != nil {
+				return Escalation{}, false, fmt.Errorf("notifying lead %s: %w", e.LeadUserID, err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO pr_escalations (pull_request_id, slot, hops, lead_notified_at, updated_at)
+        VALUES ($1, $2, $3, CASE WHEN $4 THEN now() END, now())
+        ON CONFLICT (pull_request_id, slot) DO UPDATE SET
+            hops = EXCLUDED.hops, lead_notified_at = EXCLUDED.lead_notified_at, updated_at = now()
+`, e.PullRequestID, e.Slot, e.Hops, e.LeadNotified); err != nil {
+		return Escalation{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Escalation{}, false, err
+	}
+	return e, true, nil
+}
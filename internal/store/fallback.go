@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// pick is a selected reviewer; FallbackTeam is set when the reviewer came
+// from one of the team's fallback teams, OwnerTeam or OwnerUser when they
+// were seated to cover a team or user owning some of the changed files.
+type pick struct {
+	UserID       string
+	FallbackTeam string
+	OwnerTeam    string
+	OwnerUser    string
+}
+
+func teamFallbacks(ctx context.Context, q sqlx.QueryerContext, teamName string) ([]string, error) {
+	fallbacks := make([]string, 0)
+	err := sqlx.SelectContext(ctx, q, &fallbacks, `SELECT fallback_team FROM team_fallbacks WHERE team_name = $1 ORDER BY position`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return fallbacks, nil
+}
+
+// pickWithFallback picks up to k reviewers from the team and, while slots
+// remain, from the team's fallback teams in order. saturated counts the
+// candidates skipped across all those teams because they were at capacity.
+func (s *Store) pickWithFallback(ctx context.Context, tx *sqlx.Tx, teamName string, exclude, labels []string, k int) (picked []pick, saturated int, err error) {
+	own, saturated, err := s.pickReviewers(ctx, tx, teamName, exclude, labels, k)
+	if err != nil {
+		return nil, 0, err
+	}
+	picked = make([]pick, 0, k)
+	for _, uid := range own {
+		picked = append(picked, pick{UserID: uid})
+	}
+	if len(picked) >= k {
+		return picked, saturated, nil
+	}
+
+	fallbacks, err := teamFallbacks(ctx, tx, teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+	skip := append(append(make([]string, 0, len(exclude)+k), exclude...), own...)
+	for _, fb := range fallbacks {
+		more, fbSaturated, err := s.pickReviewers(ctx, tx, fb, skip, labels, k-len(picked))
+		if err != nil {
+			return nil, 0, err
+		}
+		saturated += fbSaturated
+		for _, uid := range more {
+			picked = append(picked, pick{UserID: uid, FallbackTeam: fb})
+			skip = append(skip, uid)
+		}
+		if len(picked) >= k {
+			break
+		}
+	}
+	return picked, saturated, nil
+}
+
+// SetTeamFallbacks replaces the ordered list of teams the team borrows
+// reviewers from when its own pool runs out.
+func (s *Store) SetTeamFallbacks(ctx context.Context, teamName string, fallbacks []string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in SetTeamFallbacks: %v", rollbackErr)
+		}
+	}()
+
+	var n int
+	if err := tx.GetContext(ctx, &n, `SELECT COUNT(*) FROM teams WHERE name = ANY($1)`, pq.Array(append([]string{teamName}, fallbacks...))); err != nil {
+		return err
+	}
+	if n != len(uniqueStrings(append([]string{teamName}, fallbacks...))) {
+		return ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_fallbacks WHERE team_name = $1`, teamName); err != nil {
+		return err
+	}
+	for i, fb := range fallbacks {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO team_fallbacks (team_name, fallback_team, position) VALUES ($1,$2,$3)`, teamName, fb, i+1); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// Reasons recorded with assignment events the store makes on its own.
+const (
+	reasonAutoAssigned     = "auto-assigned"
+	reasonPinned           = "required by the authors"
+	reasonCodeOwner        = "code owner of changed files"
+	reasonFallback         = "picked from fallback team"
+	reasonReplaced         = "replaced by auto-assignment"
+	reasonManualAssign     = "assigned manually"
+	reasonManualReplace    = "replaced by manual assignment"
+	reasonManualAdd        = "added manually"
+	reasonManualRemove     = "removed manually"
+	reasonManualReassign   = "reassigned manually"
+	reasonSlotReleased     = "team requires fewer reviewers"
+	reasonPRClosed         = "pull request closed"
+	reasonDeactivated      = "reviewer deactivated"
+	reasonAbsent           = "reviewer absent"
+	reasonMovedTeam        = "reviewer moved to another team"
+	reasonReviewEscalation = "review overdue"
+)
+
+type actorKey struct{}
+
+// WithActor attaches the user or system component making the change to ctx;
+// assignment events written under ctx record it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// eventCause is how a successful reassignment is recorded.
+type eventCause struct {
+	Kind   domain.AssignmentEventKind
+	Reason string
+}
+
+func logAssignment(ctx context.Context, tx *sqlx.Tx, e domain.AssignmentEvent) error {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO assignment_events (pull_request_id, kind, slot, user_id, previous_user_id, actor, reason, created_at)
+        VALUES ($1,$2,$3,$4,NULLIF($5, ''),NULLIF($6, ''),$7,now())
+`, e.PullRequestID, e.Kind, e.Slot, e.UserID, e.PreviousUserID, actorFrom(ctx), e.Reason)
+	return err
+}
+
+// clearAssignments removes every slot of the PR, recording each reviewer as
+// REMOVED for the reason given.
+func clearAssignments(ctx context.Context, tx *sqlx.Tx, prID, reason string) error {
+	_, err := tx.ExecContext(ctx, `
+        WITH removed AS (
+            DELETE FROM pr_assignments WHERE pull_request_id = $1 RETURNING pull_request_id, slot, user_id
+        )
+        INSERT INTO assignment_events (pull_request_id, kind, slot, user_id, actor, reason, created_at)
+        SELECT pull_request_id, $2::text, slot, user_id, NULLIF($3::text, ''), $4::text, now() FROM removed
+`, prID, domain.EventRemoved, actorFrom(ctx), reason)
+	return err
+}
+
+// PRHistory returns the assignment events of the PR, oldest first.
+func (s *Store) PRHistory(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	var exists bool
+	// the history of a deleted PR is still there to read
+	if err := s.db.GetContext(ctx, &exists, `
+        SELECT EXISTS (SELECT 1 FROM prs WHERE pull_request_id = $1)
+            OR EXISTS (SELECT 1 FROM assignment_events WHERE pull_request_id = $1)
+`, prID); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	events := make([]domain.AssignmentEvent, 0)
+	if err := s.db.SelectContext(ctx, &events, `
+        SELECT event_id, pull_request_id, kind, slot, user_id, COALESCE(previous_user_id, '') AS previous_user_id,
+            COALESCE(actor, '') AS actor, reason, created_at
+        FROM assignment_events
+        WHERE pull_request_id = $1
+        ORDER BY event_id
+`, prID); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// logReassignments records a batch of slots handed from old to new reviewers.
+func logReassignments(ctx context.Context, tx *sqlx.Tx, cause eventCause, prIDs []string, slots []int64, olds, news []string) error {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO assignment_events (pull_request_id, kind, slot, user_id, previous_user_id, actor, reason, created_at)
+        SELECT v.pull_request_id, $5::text, v.slot, v.user_id, v.previous_user_id, NULLIF($6::text, ''), $7::text, now()
+        FROM unnest($1::text[], $2::smallint[], $3::text[], $4::text[]) AS v(pull_request_id, slot, user_id, previous_user_id)
+`, pq.Array(prIDs), pq.Array(slots), pq.Array(news), pq.Array(olds), cause.Kind, actorFrom(ctx), cause.Reason)
+	return err
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// lockPRStatus locks the PR row for the rest of the transaction and returns
+// its current status.
+func lockPRStatus(ctx context.Context, tx *sqlx.Tx, prID string) (domain.PRStatus, error) {
+	var status domain.PRStatus
+	if err := tx.GetContext(ctx, &status, `SELECT status FROM prs WHERE pull_request_id = $1 FOR UPDATE`, prID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+func requireOpen(status domain.PRStatus) error {
+	switch status {
+	case domain.StatusOpen:
+		return nil
+	case domain.StatusMerged:
+		return ErrPRMerged
+	default:
+		return ErrPRNotOpen
+	}
+}
+
+// autoAssign seats reviewers from the author's team on every slot the team
+// requires, after the pinned reviewers and one reviewer for each owner of
+// the changed files. Members skilled in the PR's labels go first; the
+// authors, the users they excluded and users in conflict with any of them
+// are never picked. It fails with ErrCapacityExhausted when no free
+// candidate is left for the open slots because the others are at their
+// review limit.
+func (s *Store) autoAssign(ctx context.Context, tx *sqlx.Tx, prID string) error {
+	var target struct {
+		TeamName          string `db:"team_name"`
+		RequiredReviewers int    `db:"required_reviewers"`
+	}
+	if err := tx.GetContext(ctx, &target, `
+        SELECT u.team_name, t.required_reviewers FROM prs p
+        JOIN users u ON u.user_id = p.author_id
+        JOIN teams t ON t.name = u.team_name
+        WHERE p.pull_request_id = $1
+`, prID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	labels, err := prLabels(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+
+	// every team a seat may come from: the author's team, its fallbacks and
+	// the teams owning the changed files
+	fallbacks, err := teamFallbacks(ctx, tx, target.TeamName)
+	if err != nil {
+		return err
+	}
+	owning, err := fileOwners(ctx, tx, prID, target.TeamName)
+	if err != nil {
+		return err
+	}
+	var ownerUserTeams []string
+	if err := tx.SelectContext(ctx, &ownerUserTeams, `SELECT team_name FROM users WHERE user_id = ANY($1)`, pq.Array(owning.Users)); err != nil {
+		return err
+	}
+	teams := append(append(append([]string{target.TeamName}, fallbacks...), owning.Teams...), ownerUserTeams...)
+	if _, err := lockRotations(ctx, tx, teams); err != nil {
+		return err
+	}
+
+	// pinned reviewers are seated first, then the owners of the changed
+	// files, and the rest of the slots come from the author's team as usual
+	selected, err := pinnedSeats(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	pinned := len(selected)
+	var excluded []string
+	if err := tx.SelectContext(ctx, &excluded, `SELECT user_id FROM pr_reviewer_preferences WHERE pull_request_id = $1 AND kind = $2`,
+		prID, preferenceExcluded); err != nil {
+		return err
+	}
+	conflicted, err := authorConflicts(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	authors, err := prAuthors(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	exclude := append(append(authors, excluded...), conflicted...)
+	for _, p := range selected {
+		exclude = append(exclude, p.UserID)
+	}
+
+	owners, err := s.ownerSeats(ctx, tx, prID, target.TeamName, selected, exclude, labels)
+	if err != nil {
+		return err
+	}
+	for _, p := range owners {
+		selected = append(selected, p)
+		exclude = append(exclude, p.UserID)
+	}
+
+	if remaining := target.RequiredReviewers - len(selected); remaining > 0 {
+		// whoever is free is seated and a small team leaves slots empty, but
+		// a team whose candidates are all at capacity is refused so the PR
+		// is not left waiting on nobody
+		more, saturated, err := s.pickWithFallback(ctx, tx, target.TeamName, exclude, labels, remaining)
+		if err != nil {
+			return err
+		}
+		if len(more) == 0 && saturated > 0 {
+			return ErrCapacityExhausted
+		}
+		selected = append(selected, more...)
+	}
+
+	if err := clearAssignments(ctx, tx, prID, reasonReplaced); err != nil {
+		return err
+	}
+	for i, p := range selected {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_assignments (pull_request_id, user_id, slot, fallback_team, owner_team, owner_user, assigned_at) VALUES ($1,$2,$3,NULLIF($4, ''),NULLIF($5, ''),NULLIF($6, ''),now())`,
+			prID, p.UserID, i+1, p.FallbackTeam, p.OwnerTeam, p.OwnerUser); err != nil {
+			return err
+		}
+		reason := reasonAutoAssigned
+		switch {
+		case i < pinned:
+			reason = reasonPinned
+		case p.OwnerTeam != "" || p.OwnerUser != "":
+			reason = reasonCodeOwner
+		case p.FallbackTeam != "":
+			reason = reasonFallback
+		}
+		if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+			PullRequestID: prID, Kind: domain.EventAssigned, Slot: i + 1, UserID: domain.UserID(p.UserID), Reason: reason,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TransitionPR moves the PR from one status to another. Leaving DRAFT or
+// reopening a closed PR assigns reviewers; closing releases the current
+// assignments. Merging goes through SetPRMerged because of the merge policy.
+func (s *Store) TransitionPR(ctx context.Context, prID string, from, to domain.PRStatus) (*domain.PullRequest, error) {
+	if to == domain.StatusMerged {
+		return nil, domain.ErrInvalidTransition
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in TransitionPR: %v", rollbackErr)
+		}
+	}()
+
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domain.StatusMerged {
+		return nil, ErrPRMerged
+	}
+	if status != from || !status.CanTransitionTo(to) {
+		return nil, domain.ErrInvalidTransition
+	}
+
+	switch to {
+	case domain.StatusOpen:
+		if _, err := tx.ExecContext(ctx, `UPDATE prs SET status = $1, closed_at = NULL WHERE pull_request_id = $2`, to, prID); err != nil {
+			return nil, err
+		}
+		if err := s.autoAssign(ctx, tx, prID); err != nil {
+			return nil, err
+		}
+	case domain.StatusClosed:
+		if _, err := tx.ExecContext(ctx, `UPDATE prs SET status = $1, closed_at = now() WHERE pull_request_id = $2`, to, prID); err != nil {
+			return nil, err
+		}
+		if err := clearAssignments(ctx, tx, prID, reasonPRClosed); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetPR(ctx, prID)
+}
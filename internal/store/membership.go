@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+var (
+	ErrAlreadyInTeam = errors.New("already in team")
+	ErrMemberChange  = errors.New("member change not allowed")
+)
+
+// MemberChangeError names the field of an existing user that adding them to
+// a team would have changed.
+type MemberChangeError struct {
+	UserID string
+	Field  string
+}
+
+func (e *MemberChangeError) Error() string {
+	return ErrMemberChange.Error() + ": " + e.UserID + " " + e.Field
+}
+
+func (e *MemberChangeError) Unwrap() error {
+	return ErrMemberChange
+}
+
+// MoveUserTeam moves the user to another team and records the move. With
+// reassign set, the user's OPEN review slots are first handed to active
+// members of the old team; otherwise the user keeps them.
+func (s *Store) MoveUserTeam(ctx context.Context, userID, target string, reassign bool) (*domain.User, []Reassignment, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in MoveUserTeam: %v", rollbackErr)
+		}
+	}()
+
+	var current string
+	if err := tx.GetContext(ctx, &current, `SELECT team_name FROM users WHERE user_id = $1 FOR UPDATE`, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	if current == target {
+		return nil, nil, ErrAlreadyInTeam
+	}
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, target); err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, ErrNotFound
+	}
+
+	reassignments, err := s.moveUser(ctx, tx, userID, current, target, reassign)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	u, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, reassignments, nil
+}
+
+// moveUser moves a user locked in tx from one team to another and records
+// the move. With reassign set, the user's OPEN review slots are first handed
+// on; otherwise the user keeps them.
+func (s *Store) moveUser(ctx context.Context, tx *sqlx.Tx, userID, from, to string, reassign bool) ([]Reassignment, error) {
+	reassignments := make([]Reassignment, 0)
+	if reassign {
+		// the user is still active and assigned here, so they are never
+		// picked as their own replacement
+		var err error
+		reassignments, err = s.reassignOpenSlots(ctx, tx, userID, eventCause{Kind: domain.EventReassigned, Reason: reasonMovedTeam})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET team_name = $1, updated_at = now() WHERE user_id = $2`, to, userID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO user_team_history (user_id, from_team, to_team, moved_at) VALUES ($1,$2,$3,now())`,
+		userID, from, to); err != nil {
+		return nil, err
+	}
+	return reassignments, nil
+}
+
+func (s *Store) GetTeamHistory(ctx context.Context, userID string) ([]domain.TeamMembership, error) {
+	history := make([]domain.TeamMembership, 0)
+	err := s.db.SelectContext(ctx, &history, `
+        SELECT user_id, COALESCE(from_team, '') AS from_team, to_team, moved_at
+        FROM user_team_history WHERE user_id = $1
+        ORDER BY moved_at, id
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/selection"
+)
+
+type ownershipRuleRow struct {
+	domain.OwnershipRule
+	OwnerTeams pq.StringArray `db:"owner_teams"`
+	OwnerUsers pq.StringArray `db:"owner_users"`
+}
+
+func (r ownershipRuleRow) rule() domain.OwnershipRule {
+	rule := r.OwnershipRule
+	rule.OwnerTeams = append(make([]string, 0, len(r.OwnerTeams)), r.OwnerTeams...)
+	rule.OwnerUsers = append(make([]string, 0, len(r.OwnerUsers)), r.OwnerUsers...)
+	return rule
+}
+
+// ownersExist reports whether every owner team and owner user is known.
+func ownersExist(ctx context.Context, q sqlx.QueryerContext, teams, users []string) (bool, error) {
+	var ok bool
+	err := sqlx.GetContext(ctx, q, &ok, `
+        SELECT (SELECT COUNT(*) FROM teams WHERE name = ANY($1)) = $2
+           AND (SELECT COUNT(*) FROM users WHERE user_id = ANY($3)) = $4
+`, pq.Array(teams), len(uniqueStrings(teams)), pq.Array(users), len(uniqueStrings(users)))
+	return ok, err
+}
+
+func (s *Store) CreateOwnershipRule(ctx context.Context, r *domain.OwnershipRule) (*domain.OwnershipRule, error) {
+	ok, err := ownersExist(ctx, s.db, r.OwnerTeams, r.OwnerUsers)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var saved ownershipRuleRow
+	err = s.db.GetContext(ctx, &saved, `
+        INSERT INTO ownership_rules (team_name, pattern, owner_teams, owner_users, created_at)
+        SELECT name, $2, $3, $4, now() FROM teams WHERE name = $1
+        RETURNING rule_id, team_name, pattern, owner_teams, owner_users, created_at
+`, r.TeamName, r.Pattern, pq.Array(r.OwnerTeams), pq.Array(r.OwnerUsers))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	rule := saved.rule()
+	return &rule, nil
+}
+
+// UpdateOwnershipRule replaces the pattern and owners of a rule. The rule
+// keeps its place in the team's list.
+func (s *Store) UpdateOwnershipRule(ctx context.Context, id int64, r *domain.OwnershipRule) (*domain.OwnershipRule, error) {
+	ok, err := ownersExist(ctx, s.db, r.OwnerTeams, r.OwnerUsers)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var saved ownershipRuleRow
+	err = s.db.GetContext(ctx, &saved, `
+        UPDATE ownership_rules SET pattern = $2, owner_teams = $3, owner_users = $4
+        WHERE rule_id = $1
+        RETURNING rule_id, team_name, pattern, owner_teams, owner_users, created_at
+`, id, r.Pattern, pq.Array(r.OwnerTeams), pq.Array(r.OwnerUsers))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	rule := saved.rule()
+	return &rule, nil
+}
+
+func (s *Store) DeleteOwnershipRule(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM ownership_rules WHERE rule_id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) ListOwnershipRules(ctx context.Context, teamName string) ([]domain.OwnershipRule, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, teamName); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return ownershipRules(ctx, s.db, teamName)
+}
+
+// PreviewOwnership shows which of the team's rules owns each path without
+// touching any PR.
+func (s *Store) PreviewOwnership(ctx context.Context, teamName string, paths []string) ([]domain.PathMatch, domain.Owners, error) {
+	rules, err := s.ListOwnershipRules(ctx, teamName)
+	if err != nil {
+		return nil, domain.Owners{}, err
+	}
+	matches, owners := domain.MatchPaths(rules, paths)
+	return matches, owners, nil
+}
+
+// ownershipRules returns the team's rules in the order they were added.
+func ownershipRules(ctx context.Context, q sqlx.QueryerContext, teamName string) ([]domain.OwnershipRule, error) {
+	var rows []ownershipRuleRow
+	if err := sqlx.SelectContext(ctx, q, &rows, `
+        SELECT rule_id, team_name, pattern, owner_teams, owner_users, created_at
+        FROM ownership_rules WHERE team_name = $1
+        ORDER BY rule_id
+`, teamName); err != nil {
+		return nil, err
+	}
+	rules := make([]domain.OwnershipRule, 0, len(rows))
+	for _, r := range rows {
+		rules = append(rules, r.rule())
+	}
+	return rules, nil
+}
+
+// fileOwners returns the owners of the PR's changed files under the rules of
+// the team.
+func fileOwners(ctx context.Context, tx *sqlx.Tx, prID, teamName string) (domain.Owners, error) {
+	var files []string
+	if err := tx.SelectContext(ctx, &files, `SELECT path FROM pr_files WHERE pull_request_id = $1 ORDER BY path`, prID); err != nil {
+		return domain.Owners{}, err
+	}
+	if len(files) == 0 {
+		return domain.Owners{Teams: make([]string, 0), Users: make([]string, 0)}, nil
+	}
+	rules, err := ownershipRules(ctx, tx, teamName)
+	if err != nil {
+		return domain.Owners{}, err
+	}
+	_, owners := domain.MatchPaths(rules, files)
+	return owners, nil
+}
+
+// ownerSeats picks one reviewer for every owner of the PR's changed files,
+// using the rules of the author's team. An owning user is seated directly
+// when they can take the review; an owning team is covered by any seated
+// member, including the already seated reviewers. Owners nobody can stand
+// in for are skipped.
+func (s *Store) ownerSeats(ctx context.Context, tx *sqlx.Tx, prID, teamName string, seated []pick, exclude, labels []string) ([]pick, error) {
+	owners, err := fileOwners(ctx, tx, prID, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if len(owners.Teams) == 0 && len(owners.Users) == 0 {
+		return nil, nil
+	}
+
+	// rules are not tied to teams by a foreign key; an owner team that has
+	// since been deleted has nobody to offer
+	var ownerTeams []string
+	if err := tx.SelectContext(ctx, &ownerTeams, `SELECT name FROM teams WHERE name = ANY($1)`, pq.Array(owners.Teams)); err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(ownerTeams))
+	for _, t := range ownerTeams {
+		existing[t] = true
+	}
+
+	seatedIDs := make([]string, 0, len(seated))
+	for _, p := range seated {
+		seatedIDs = append(seatedIDs, p.UserID)
+	}
+	var seatedTeams []string
+	if err := tx.SelectContext(ctx, &seatedTeams, `SELECT team_name FROM users WHERE user_id = ANY($1)`, pq.Array(seatedIDs)); err != nil {
+		return nil, err
+	}
+	covered := make(map[string]bool)
+	for _, t := range seatedTeams {
+		covered[t] = true
+	}
+	onPR := make(map[string]bool)
+	for _, uid := range seatedIDs {
+		onPR[uid] = true
+	}
+
+	seats := make([]pick, 0, len(owners.Users)+len(owners.Teams))
+	taken := append(make([]string, 0, len(exclude)+len(owners.Users)), exclude...)
+	for _, uid := range owners.Users {
+		if onPR[uid] {
+			continue
+		}
+		var userTeam string
+		if err := tx.GetContext(ctx, &userTeam, `SELECT team_name FROM users WHERE user_id = $1`, uid); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		pool, err := candidatePool(ctx, tx, userTeam, taken, nil)
+		if err != nil {
+			return nil, err
+		}
+		free, _ := selection.Available(pool)
+		for _, c := range free {
+			if c.UserID == uid {
+				seats = append(seats, pick{UserID: uid, OwnerUser: uid})
+				taken = append(taken, uid)
+				covered[userTeam] = true
+				break
+			}
+		}
+	}
+	for _, team := range owners.Teams {
+		if covered[team] || !existing[team] {
+			continue
+		}
+		picked, _, err := s.pickReviewers(ctx, tx, team, taken, labels, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(picked) > 0 {
+			seats = append(seats, pick{UserID: picked[0], OwnerTeam: team})
+			taken = append(taken, picked[0])
+			covered[team] = true
+		}
+	}
+	return seats, nil
+}
+
+// slotOwner is the owner a review slot was seated for. A slot covering an
+// owning user is handed over within that user's team first, the same way an
+// owning team's slot stays with the team.
+type slotOwner struct {
+	Team     string `db:"owner_team"`
+	User     string `db:"owner_user"`
+	UserTeam string `db:"owner_user_team"`
+}
+
+func (o slotOwner) covered() bool {
+	return o.Team != "" || o.User != ""
+}
+
+// pool is the team replacements for the slot are offered from first.
+func (o slotOwner) pool() string {
+	if o.Team != "" {
+		return o.Team
+	}
+	return o.UserTeam
+}
+
+// mark records the owner on a pick made from the owner's pool.
+func (o slotOwner) mark(p pick) pick {
+	p.OwnerTeam, p.OwnerUser = o.Team, o.User
+	return p
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+const (
+	preferenceRequired = "REQUIRED"
+	preferenceExcluded = "EXCLUDED"
+)
+
+// saveReviewerPreferences stores the reviewers the author pinned or excluded
+// on creation. Every listed user must exist, and pinned users must be active,
+// free of conflicts with the authors and fit in the slots the author's team
+// requires.
+func saveReviewerPreferences(ctx context.Context, tx *sqlx.Tx, pr *domain.PullRequest) error {
+	if len(pr.PinnedReviewers)+len(pr.ExcludedReviewers) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(pr.PinnedReviewers)+len(pr.ExcludedReviewers))
+	pinned := make([]string, 0, len(pr.PinnedReviewers))
+	for _, uid := range pr.PinnedReviewers {
+		ids = append(ids, string(uid))
+		pinned = append(pinned, string(uid))
+	}
+	for _, uid := range pr.ExcludedReviewers {
+		ids = append(ids, string(uid))
+	}
+
+	var users []struct {
+		UserID   string `db:"user_id"`
+		IsActive bool   `db:"is_active"`
+	}
+	if err := tx.SelectContext(ctx, &users, `SELECT user_id, is_active FROM users WHERE user_id = ANY($1)`, pq.Array(ids)); err != nil {
+		return err
+	}
+	if len(users) != len(uniqueStrings(ids)) {
+		return ErrNotFound
+	}
+	active := make(map[string]bool, len(users))
+	for _, u := range users {
+		active[u.UserID] = u.IsActive
+	}
+	for _, uid := range pinned {
+		if !active[uid] {
+			return ErrReviewerInactive
+		}
+	}
+	conflicted, err := authorConflicts(ctx, tx, pr.ID)
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicted {
+		for _, uid := range pinned {
+			if c == uid {
+				return ErrConflictOfInterest
+			}
+		}
+	}
+
+	if len(pinned) > 0 {
+		var required int
+		if err := tx.GetContext(ctx, &required, `
+            SELECT t.required_reviewers FROM users u JOIN teams t ON t.name = u.team_name
+            WHERE u.user_id = $1
+`, pr.AuthorID); err != nil {
+			return err
+		}
+		if len(pinned) > required {
+			return ErrTooManyReviewers
+		}
+	}
+
+	for i, uid := range pr.PinnedReviewers {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_reviewer_preferences (pull_request_id, user_id, kind, position) VALUES ($1,$2,$3,$4)`,
+			pr.ID, uid, preferenceRequired, i+1); err != nil {
+			return err
+		}
+	}
+	for _, uid := range pr.ExcludedReviewers {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_reviewer_preferences (pull_request_id, user_id, kind) VALUES ($1,$2,$3)`,
+			pr.ID, uid, preferenceExcluded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadReviewerPreferences fills the PR's pinned and excluded reviewers.
+func loadReviewerPreferences(ctx context.Context, q sqlx.QueryerContext, pr *domain.PullRequest) error {
+	var prefs []struct {
+		UserID string `db:"user_id"`
+		Kind   string `db:"kind"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &prefs, `
+        SELECT user_id, kind FROM pr_reviewer_preferences
+        WHERE pull_request_id = $1 ORDER BY position, user_id
+`, pr.ID); err != nil {
+		return err
+	}
+	pr.PinnedReviewers = make([]domain.UserID, 0)
+	pr.ExcludedReviewers = make([]domain.UserID, 0)
+	for _, p := range prefs {
+		if p.Kind == preferenceRequired {
+			pr.PinnedReviewers = append(pr.PinnedReviewers, domain.UserID(p.UserID))
+		} else {
+			pr.ExcludedReviewers = append(pr.ExcludedReviewers, domain.UserID(p.UserID))
+		}
+	}
+	return nil
+}
+
+// pinnedSeats returns the PR's pinned reviewers who are still active and not
+// in conflict with an author, in the order the author gave them. They are
+// seated regardless of load.
+func pinnedSeats(ctx context.Context, tx *sqlx.Tx, prID string) ([]pick, error) {
+	var pinned []string
+	if err := tx.SelectContext(ctx, &pinned, `
+        SELECT pref.user_id FROM pr_reviewer_preferences pref
+        JOIN users u ON u.user_id = pref.user_id
+        WHERE pref.pull_request_id = $1 AND pref.kind = $2 AND u.is_active = true
+          AND NOT EXISTS (
+              SELECT 1 FROM user_conflicts c JOIN pr_authors pa ON pa.user_id IN (c.user_a, c.user_b)
+              WHERE pa.pull_request_id = pref.pull_request_id AND u.user_id IN (c.user_a, c.user_b) AND u.user_id <> pa.user_id
+          )
+        ORDER BY pref.position
+`, prID, preferenceRequired); err != nil {
+		return nil, err
+	}
+	seats := make([]pick, 0, len(pinned))
+	for _, uid := range pinned {
+		seats = append(seats, pick{UserID: uid})
+	}
+	return seats, nil
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func (s *Store) SubmitReview(ctx context.Context, r *domain.Review) (*domain.Review, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in SubmitReview: %v", rollbackErr)
+		}
+	}()
+
+	status, err := lockPRStatus(ctx, tx, r.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOpen(status); err != nil {
+		return nil, err
+	}
+
+	var assigned bool
+	if err := tx.GetContext(ctx, &assigned, `SELECT EXISTS (SELECT 1 FROM pr_assignments WHERE pull_request_id = $1 AND user_id = $2)`,
+		r.PullRequestID, r.ReviewerID); err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	var saved domain.Review
+	if err := tx.GetContext(ctx, &saved, `
+        INSERT INTO pr_reviews (pull_request_id, user_id, verdict, body, created_at)
+        VALUES ($1,$2,$3,$4,now())
+        RETURNING pull_request_id, user_id, verdict, body, created_at
+`, r.PullRequestID, r.ReviewerID, r.Verdict, r.Body); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// loadReviewers fills the assigned reviewers of the PR, the ones borrowed from
+// fallback teams and the latest verdict each of them has submitted.
+func loadReviewers(ctx context.Context, q sqlx.QueryerContext, pr *domain.PullRequest) error {
+	var reviewers []struct {
+		UserID       string `db:"user_id"`
+		FallbackTeam string `db:"fallback_team"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &reviewers, `SELECT user_id, COALESCE(fallback_team, '') AS fallback_team FROM pr_assignments WHERE pull_request_id = $1 ORDER BY slot ASC`, pr.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	pr.AssignedReviewers = make([]domain.UserID, 0, len(reviewers))
+	pr.FallbackReviewers = make([]domain.FallbackReviewer, 0)
+	for _, r := range reviewers {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, domain.UserID(r.UserID))
+		if r.FallbackTeam != "" {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, domain.FallbackReviewer{
+				UserID:   domain.UserID(r.UserID),
+				TeamName: domain.TeamID(r.FallbackTeam),
+			})
+		}
+	}
+
+	pr.Reviews = make([]domain.Review, 0, len(reviewers))
+	if err := sqlx.SelectContext(ctx, q, &pr.Reviews, `
+        SELECT DISTINCT ON (r.user_id) r.pull_request_id, r.user_id, r.verdict, r.body, r.created_at
+        FROM pr_reviews r
+        JOIN pr_assignments a ON a.pull_request_id = r.pull_request_id AND a.user_id = r.user_id
+        WHERE r.pull_request_id = $1
+        ORDER BY r.user_id, r.created_at DESC, r.review_id DESC
+`, pr.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// assignedReviews returns every review the currently assigned reviewers have
+// submitted on the PR, oldest first.
+func assignedReviews(ctx context.Context, q sqlx.QueryerContext, prID string) ([]domain.Review, error) {
+	reviews := make([]domain.Review, 0)
+	if err := sqlx.SelectContext(ctx, q, &reviews, `
+        SELECT r.pull_request_id, r.user_id, r.verdict, r.body, r.created_at
+        FROM pr_reviews r
+        JOIN pr_assignments a ON a.pull_request_id = r.pull_request_id AND a.user_id = r.user_id
+        WHERE r.pull_request_id = $1
+        ORDER BY r.created_at, r.review_id
+`, prID); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+var (
+	ErrReviewerIsAuthor = errors.New("reviewer is the author")
+	ErrAlreadyAssigned  = errors.New("reviewer already assigned")
+	ErrReviewerExcluded = errors.New("reviewer excluded by the author")
+)
+
+// AddReviewer seats the user on the first free slot of an OPEN PR. The PR row
+// is locked like in SetPRMerged, so the change cannot race a merge. Authors,
+// inactive users, users the authors excluded and users in conflict with them
+// are refused.
+func (s *Store) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in AddReviewer: %v", rollbackErr)
+		}
+	}()
+
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOpen(status); err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		IsActive   bool `db:"is_active"`
+		IsAuthor   bool `db:"is_author"`
+		Assigned   bool `db:"assigned"`
+		Excluded   bool `db:"excluded"`
+		Conflicted bool `db:"conflicted"`
+	}
+	if err := tx.GetContext(ctx, &user, `
+        SELECT u.is_active,
+            EXISTS (SELECT 1 FROM pr_authors pa WHERE pa.pull_request_id = p.pull_request_id AND pa.user_id = u.user_id) AS is_author,
+            EXISTS (SELECT 1 FROM pr_assignments a WHERE a.pull_request_id = p.pull_request_id AND a.user_id = u.user_id) AS assigned,
+            EXISTS (SELECT 1 FROM pr_reviewer_preferences pref
+                    WHERE pref.pull_request_id = p.pull_request_id AND pref.user_id = u.user_id AND pref.kind = $3) AS excluded,
+            EXISTS (SELECT 1 FROM user_conflicts c JOIN pr_authors pa ON pa.user_id IN (c.user_a, c.user_b)
+                    WHERE pa.pull_request_id = p.pull_request_id AND u.user_id IN (c.user_a, c.user_b) AND u.user_id <> pa.user_id) AS conflicted
+        FROM users u, prs p
+        WHERE u.user_id = $1 AND p.pull_request_id = $2
+`, userID, prID, preferenceExcluded); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	switch {
+	case user.IsAuthor:
+		return nil, ErrReviewerIsAuthor
+	case !user.IsActive:
+		return nil, ErrReviewerInactive
+	case user.Assigned:
+		return nil, ErrAlreadyAssigned
+	case user.Excluded:
+		return nil, ErrReviewerExcluded
+	case user.Conflicted:
+		return nil, ErrConflictOfInterest
+	}
+
+	required, err := requiredReviewers(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	var seated int
+	if err := tx.GetContext(ctx, &seated, `SELECT COUNT(*) FROM pr_assignments WHERE pull_request_id = $1`, prID); err != nil {
+		return nil, err
+	}
+	if seated >= required {
+		return nil, ErrTooManyReviewers
+	}
+
+	var slot int
+	if err := tx.GetContext(ctx, &slot, `
+        INSERT INTO pr_assignments (pull_request_id, user_id, slot, assigned_at)
+        SELECT $1, $2, MIN(s), now() FROM generate_series(1, $3::int) s
+        WHERE s NOT IN (SELECT slot FROM pr_assignments WHERE pull_request_id = $1)
+        RETURNING slot
+`, prID, userID, seated+1); err != nil {
+		return nil, err
+	}
+	if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID, Kind: domain.EventAssigned, Slot: slot, UserID: domain.UserID(userID), Reason: reasonManualAdd,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetPR(ctx, prID)
+}
+
+// RemoveReviewer takes the user off an OPEN PR and leaves the slot empty.
+func (s *Store) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in RemoveReviewer: %v", rollbackErr)
+		}
+	}()
+
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOpen(status); err != nil {
+		return nil, err
+	}
+
+	var slot int
+	if err := tx.GetContext(ctx, &slot, `DELETE FROM pr_assignments WHERE pull_request_id = $1 AND user_id = $2 RETURNING slot`, prID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReviewerNotAssigned
+		}
+		return nil, err
+	}
+	if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID, Kind: domain.EventRemoved, Slot: slot, UserID: domain.UserID(userID), Reason: reasonManualRemove,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return s.GetPR(ctx, prID)
+}
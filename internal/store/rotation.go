@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// rotationCursor returns the last member a ROUND_ROBIN team picked and locks
+// the cursor row until the transaction ends, so concurrent PRs of the team
+// take turns instead of picking the same member. ok is false when the team
+// does not rotate, which includes a team that no longer exists.
+func rotationCursor(ctx context.Context, tx *sqlx.Tx, teamName string) (last string, ok bool, err error) {
+	var strategy domain.SelectionStrategy
+	if err := tx.GetContext(ctx, &strategy, `SELECT selection_strategy FROM teams WHERE name = $1`, teamName); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if strategy != domain.StrategyRoundRobin {
+		return "", false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO team_rotation_cursors (team_name) VALUES ($1) ON CONFLICT DO NOTHING`, teamName); err != nil {
+		return "", false, err
+	}
+	if err := tx.GetContext(ctx, &last, `SELECT last_user_id FROM team_rotation_cursors WHERE team_name = $1 FOR UPDATE`, teamName); err != nil {
+		return "", false, err
+	}
+	return last, true, nil
+}
+
+// lockRotations locks the cursors of the teams a transaction may pick from,
+// in name order, before any of them is used. Transactions picking from
+// several ROUND_ROBIN teams then take the locks in the same order and cannot
+// deadlock on each other. It returns the cursors of the rotating teams.
+func lockRotations(ctx context.Context, tx *sqlx.Tx, teams []string) (map[string]string, error) {
+	sorted := uniqueStrings(teams)
+	sort.Strings(sorted)
+	cursors := make(map[string]string)
+	for _, team := range sorted {
+		if team == "" {
+			continue
+		}
+		last, rotating, err := rotationCursor(ctx, tx, team)
+		if err != nil {
+			return nil, err
+		}
+		if rotating {
+			cursors[team] = last
+		}
+	}
+	return cursors, nil
+}
+
+func advanceRotation(ctx context.Context, tx *sqlx.Tx, teamName, last string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE team_rotation_cursors SET last_user_id = $1, updated_at = now() WHERE team_name = $2`, last, teamName)
+	return err
+}
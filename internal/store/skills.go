@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (s *Store) AddUserSkill(ctx context.Context, userID, skill string) ([]string, error) {
+	res, err := s.db.ExecContext(ctx, `
+        INSERT INTO user_skills (user_id, skill, created_at)
+        SELECT user_id, $2, now() FROM users WHERE user_id = $1
+        ON CONFLICT DO NOTHING
+`, userID, skill)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		if _, err := s.GetUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return userSkills(ctx, s.db, userID)
+}
+
+func (s *Store) RemoveUserSkill(ctx context.Context, userID, skill string) ([]string, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM user_skills WHERE user_id = $1 AND skill = $2`, userID, skill)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, ErrNotFound
+	}
+	return userSkills(ctx, s.db, userID)
+}
+
+func (s *Store) ListUserSkills(ctx context.Context, userID string) ([]string, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return userSkills(ctx, s.db, userID)
+}
+
+func userSkills(ctx context.Context, q sqlx.QueryerContext, userID string) ([]string, error) {
+	skills := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, q, &skills, `SELECT skill FROM user_skills WHERE user_id = $1 ORDER BY skill`, userID); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+// AddPRLabel labels the PR. Labels only steer the choice of new reviewers;
+// reviewers already seated stay.
+func (s *Store) AddPRLabel(ctx context.Context, prID, label string) ([]string, error) {
+	res, err := s.db.ExecContext(ctx, `
+        INSERT INTO pr_labels (pull_request_id, label, created_at)
+        SELECT pull_request_id, $2, now() FROM prs WHERE pull_request_id = $1
+        ON CONFLICT DO NOTHING
+`, prID, label)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		var exists bool
+		if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM prs WHERE pull_request_id = $1)`, prID); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrNotFound
+		}
+	}
+	return prLabels(ctx, s.db, prID)
+}
+
+func (s *Store) RemovePRLabel(ctx context.Context, prID, label string) ([]string, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM pr_labels WHERE pull_request_id = $1 AND label = $2`, prID, label)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, ErrNotFound
+	}
+	return prLabels(ctx, s.db, prID)
+}
+
+func (s *Store) ListPRLabels(ctx context.Context, prID string) ([]string, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM prs WHERE pull_request_id = $1)`, prID); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return prLabels(ctx, s.db, prID)
+}
+
+func prLabels(ctx context.Context, q sqlx.QueryerContext, prID string) ([]string, error) {
+	labels := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, q, &labels, `SELECT label FROM pr_labels WHERE pull_request_id = $1 ORDER BY label`, prID); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
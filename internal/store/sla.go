@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+type slaRow struct {
+	TeamName      string        `db:"team_name"`
+	Hours         int           `db:"sla_hours"`
+	BusinessStart string        `db:"business_start"`
+	BusinessEnd   string        `db:"business_end"`
+	WeekendDays   pq.Int64Array `db:"weekend_days"`
+	Timezone      string        `db:"timezone"`
+}
+
+func (r slaRow) policy() *domain.SLAPolicy {
+	p := &domain.SLAPolicy{
+		TeamName:      domain.TeamID(r.TeamName),
+		Hours:         r.Hours,
+		BusinessStart: r.BusinessStart,
+		BusinessEnd:   r.BusinessEnd,
+		WeekendDays:   make([]time.Weekday, 0, len(r.WeekendDays)),
+		Timezone:      r.Timezone,
+	}
+	for _, d := range r.WeekendDays {
+		p.WeekendDays = append(p.WeekendDays, time.Weekday(d))
+	}
+	return p
+}
+
+const slaColumns = `team_name, sla_hours, to_char(business_start, 'HH24:MI') AS business_start,
+    to_char(business_end, 'HH24:MI') AS business_end, weekend_days, timezone`
+
+func (s *Store) SetTeamSLA(ctx context.Context, p *domain.SLAPolicy) (*domain.SLAPolicy, error) {
+	weekend := make([]int64, 0, len(p.WeekendDays))
+	for _, d := range p.WeekendDays {
+		weekend = append(weekend, int64(d))
+	}
+
+	var saved slaRow
+	err := s.db.GetContext(ctx, &saved, `
+        INSERT INTO team_sla_settings (team_name, sla_hours, business_start, business_end, weekend_days, timezone, updated_at)
+        SELECT name, $2, $3::time, $4::time, $5, $6, now() FROM teams WHERE name = $1
+        ON CONFLICT (team_name) DO UPDATE SET
+            sla_hours = EXCLUDED.sla_hours,
+            business_start = EXCLUDED.business_start,
+            business_end = EXCLUDED.business_end,
+            weekend_days = EXCLUDED.weekend_days,
+            timezone = EXCLUDED.timezone,
+            updated_at = now()
+        RETURNING `+slaColumns, p.TeamName, p.Hours, p.BusinessStart, p.BusinessEnd, pq.Array(weekend), p.Timezone)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return saved.policy(), nil
+}
+
+// GetTeamSLA returns the team's SLA settings, or the defaults when the team
+// has not set any.
+func (s *Store) GetTeamSLA(ctx context.Context, teamName string) (*domain.SLAPolicy, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, teamName); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	policies, err := teamSLAs(ctx, s.db, []string{teamName})
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := policies[teamName]; ok {
+		return p, nil
+	}
+	return domain.DefaultSLAPolicy(domain.TeamID(teamName)), nil
+}
+
+func teamSLAs(ctx context.Context, q sqlx.QueryerContext, teams []string) (map[string]*domain.SLAPolicy, error) {
+	var rows []slaRow
+	if err := sqlx.SelectContext(ctx, q, &rows, `SELECT `+slaColumns+` FROM team_sla_settings WHERE team_name = ANY($1)`, pq.Array(teams)); err != nil {
+		return nil, err
+	}
+	policies := make(map[string]*domain.SLAPolicy, len(rows))
+	for _, r := range rows {
+		policies[r.TeamName] = r.policy()
+	}
+	return policies, nil
+}
+
+// OverdueReviews lists the assignments on OPEN PRs whose reviewer has not
+// reviewed since being assigned and whose SLA, set by the author's team, has
+// run out by now. An empty teamName covers every team. The most overdue
+// come first.
+func (s *Store) OverdueReviews(ctx context.Context, teamName string, now time.Time) ([]domain.OverdueReview, error) {
+	return overdueReviews(ctx, s.db, teamName, now)
+}
+
+func overdueReviews(ctx context.Context, q sqlx.QueryerContext, teamName string, now time.Time) ([]domain.OverdueReview, error) {
+	// wall-clock time is never shorter than working time, so assignments
+	// younger than the SLA in plain hours cannot be overdue yet
+	var rows []struct {
+		PullRequestID   string    `db:"pull_request_id"`
+		PullRequestName string    `db:"pull_request_name"`
+		Slot            int       `db:"slot"`
+		ReviewerID      string    `db:"user_id"`
+		TeamName        string    `db:"team_name"`
+		AssignedAt      time.Time `db:"assigned_at"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &rows, `
+        SELECT a.pull_request_id, p.pull_request_name, a.slot, a.user_id, u.team_name, a.assigned_at
+        FROM pr_assignments a
+        JOIN prs p ON p.pull_request_id = a.pull_request_id
+        JOIN users u ON u.user_id = p.author_id
+        LEFT JOIN team_sla_settings sla ON sla.team_name = u.team_name
+        WHERE p.status = $1
+          AND ($2::text = '' OR u.team_name = $2)
+          AND a.assigned_at < $3::timestamptz - make_interval(hours => COALESCE(sla.sla_hours, $4))
+          AND NOT EXISTS (
+              SELECT 1 FROM pr_reviews r
+              WHERE r.pull_request_id = a.pull_request_id AND r.user_id = a.user_id AND r.created_at >= a.assigned_at
+          )
+`, domain.StatusOpen, teamName, now, domain.DefaultSLAHours); err != nil {
+		return nil, err
+	}
+
+	teams := make([]string, 0, len(rows))
+	for _, r := range rows {
+		teams = append(teams, r.TeamName)
+	}
+	policies, err := teamSLAs(ctx, q, uniqueStrings(teams))
+	if err != nil {
+		return nil, err
+	}
+
+	overdue := make([]domain.OverdueReview, 0, len(rows))
+	for _, r := range rows {
+		p, ok := policies[r.TeamName]
+		if !ok {
+			p = domain.DefaultSLAPolicy(domain.TeamID(r.TeamName))
+		}
+		due := p.DueAt(r.AssignedAt)
+		if !now.After(due) {
+			continue
+		}
+		overdue = append(overdue, domain.OverdueReview{
+			PullRequestID:   r.PullRequestID,
+			PullRequestName: r.PullRequestName,
+			Slot:            r.Slot,
+			ReviewerID:      domain.UserID(r.ReviewerID),
+			TeamName:        domain.TeamID(r.TeamName),
+			AssignedAt:      r.AssignedAt,
+			DueAt:           due,
+			OverdueBy:       p.WorkingTime(due, now),
+		})
+	}
+	sort.SliceStable(overdue, func(i, j int) bool { return overdue[i].DueAt.Before(overdue[j].DueAt) })
+	return overdue, nil
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// The statistics only count PRs whose author is currently in the filter's
+// team. PRs are placed in the range by created_at, merges by merged_at and
+// reassignments by when they happened.
+
+func (s *Store) AssignmentStats(ctx context.Context, f domain.StatsFilter) ([]domain.UserAssignments, error) {
+	stats := make([]domain.UserAssignments, 0)
+	err := s.db.SelectContext(ctx, &stats, `
+        SELECT a.user_id, COUNT(*) AS total,
+            COUNT(*) FILTER (WHERE p.status = $4) AS open,
+            COUNT(*) FILTER (WHERE p.status = $5) AS merged
+        FROM pr_assignments a
+        JOIN prs p ON p.pull_request_id = a.pull_request_id
+        JOIN users au ON au.user_id = p.author_id
+        WHERE ($1::text = '' OR au.team_name = $1)
+          AND ($2::timestamptz IS NULL OR p.created_at >= $2)
+          AND ($3::timestamptz IS NULL OR p.created_at < $3)
+        GROUP BY a.user_id
+        ORDER BY total DESC, a.user_id
+`, f.TeamName, f.From, f.To, domain.StatusOpen, domain.StatusMerged)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// AuthorStats counts PRs for every author, co-authors included; the team
+// filter matches each author's own team.
+func (s *Store) AuthorStats(ctx context.Context, f domain.StatsFilter) ([]domain.AuthorPRs, error) {
+	stats := make([]domain.AuthorPRs, 0)
+	err := s.db.SelectContext(ctx, &stats, `
+        SELECT pa.user_id AS author_id, COUNT(*) AS total,
+            COUNT(*) FILTER (WHERE p.status = $4) AS draft,
+            COUNT(*) FILTER (WHERE p.status = $5) AS open,
+            COUNT(*) FILTER (WHERE p.status = $6) AS merged,
+            COUNT(*) FILTER (WHERE p.status = $7) AS closed
+        FROM pr_authors pa
+        JOIN prs p ON p.pull_request_id = pa.pull_request_id
+        JOIN users au ON au.user_id = pa.user_id
+        WHERE ($1::text = '' OR au.team_name = $1)
+          AND ($2::timestamptz IS NULL OR p.created_at >= $2)
+          AND ($3::timestamptz IS NULL OR p.created_at < $3)
+        GROUP BY pa.user_id
+        ORDER BY total DESC, pa.user_id
+`, f.TeamName, f.From, f.To, domain.StatusDraft, domain.StatusOpen, domain.StatusMerged, domain.StatusClosed)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *Store) MergeTimeStats(ctx context.Context, f domain.StatsFilter) (*domain.MergeTimeStats, error) {
+	var stats domain.MergeTimeStats
+	err := s.db.GetContext(ctx, &stats, `
+        SELECT COUNT(*) AS merged,
+            percentile_cont(0.5) WITHIN GROUP (ORDER BY d.seconds) AS median_seconds,
+            AVG(d.seconds) AS avg_seconds,
+            percentile_cont(0.9) WITHIN GROUP (ORDER BY d.seconds) AS p90_seconds
+        FROM (
+            SELECT EXTRACT(EPOCH FROM p.merged_at - p.created_at)::float8 AS seconds
+            FROM prs p
+            JOIN users au ON au.user_id = p.author_id
+            WHERE p.status = $4 AND p.merged_at IS NOT NULL
+              AND ($1::text = '' OR au.team_name = $1)
+              AND ($2::timestamptz IS NULL OR p.merged_at >= $2)
+              AND ($3::timestamptz IS NULL OR p.merged_at < $3)
+        ) d
+`, f.TeamName, f.From, f.To, domain.StatusMerged)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ReassignmentStats returns the per-user counts and the number of
+// reassignments in total.
+func (s *Store) ReassignmentStats(ctx context.Context, f domain.StatsFilter) ([]domain.UserReassignments, int, error) {
+	var total int
+	if err := s.db.GetContext(ctx, &total, `
+        SELECT COUNT(*) FROM assignment_events e
+        JOIN prs p ON p.pull_request_id = e.pull_request_id
+        JOIN users au ON au.user_id = p.author_id
+        WHERE e.kind IN ($4, $5)
+          AND ($1::text = '' OR au.team_name = $1)
+          AND ($2::timestamptz IS NULL OR e.created_at >= $2)
+          AND ($3::timestamptz IS NULL OR e.created_at < $3)
+`, f.TeamName, f.From, f.To, domain.EventReassigned, domain.EventDeactivationReassign); err != nil {
+		return nil, 0, err
+	}
+
+	stats := make([]domain.UserReassignments, 0)
+	if err := s.db.SelectContext(ctx, &stats, `
+        WITH events AS (
+            SELECT e.previous_user_id, e.user_id FROM assignment_events e
+            JOIN prs p ON p.pull_request_id = e.pull_request_id
+            JOIN users au ON au.user_id = p.author_id
+            WHERE e.kind IN ($4, $5)
+              AND ($1::text = '' OR au.team_name = $1)
+              AND ($2::timestamptz IS NULL OR e.created_at >= $2)
+              AND ($3::timestamptz IS NULL OR e.created_at < $3)
+        )
+        SELECT user_id, SUM(away)::int AS reassigned_away, SUM(received)::int AS reassigned_to
+        FROM (
+            SELECT previous_user_id AS user_id, 1 AS away, 0 AS received FROM events
+            UNION ALL
+            SELECT user_id, 0, 1 FROM events
+        ) u
+        GROUP BY user_id
+        ORDER BY reassigned_away DESC, user_id
+`, f.TeamName, f.From, f.To, domain.EventReassigned, domain.EventDeactivationReassign); err != nil {
+		return nil, 0, err
+	}
+	return stats, total, nil
+}
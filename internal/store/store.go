@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/selection"
 )
 
 var (
@@ -16,12 +19,53 @@ var (
 	ErrAlreadyExists = errors.New("already exists")
 
 	ErrPRMerged            = errors.New("pr merged")
+	ErrPRNotOpen           = errors.New("pr not open")
 	ErrReviewerNotAssigned = errors.New("reviewer not assigned")
 	ErrNoCandidate         = errors.New("no candidate")
+	ErrCapacityExhausted   = errors.New("capacity exhausted")
+	ErrTooManyReviewers    = errors.New("too many reviewers")
+	ErrReviewerInactive    = errors.New("reviewer inactive")
+	ErrMergeBlocked        = errors.New("merge blocked")
 )
 
+// MergeBlockedError lists the merge policy rules a PR does not satisfy.
+type MergeBlockedError struct {
+	Unmet []string
+}
+
+func (e *MergeBlockedError) Error() string {
+	return ErrMergeBlocked.Error() + ": " + strings.Join(e.Unmet, "; ")
+}
+
+func (e *MergeBlockedError) Unwrap() error {
+	return ErrMergeBlocked
+}
+
+// Reassignment is the outcome of moving one review slot away from a user.
+// An empty NewUserID without NoCandidate means the slot was released.
+// CapacityExhausted is set along with NoCandidate when the only candidates
+// left were at their review limit.
+type Reassignment struct {
+	PullRequestID     string `json:"pull_request_id"`
+	OldUserID         string `json:"old_user_id"`
+	NewUserID         string `json:"new_user_id,omitempty"`
+	NoCandidate       bool   `json:"no_candidate,omitempty"`
+	CapacityExhausted bool   `json:"capacity_exhausted,omitempty"`
+}
+
+// TeamUpdate holds the team settings to change; nil fields are left as is.
+type TeamUpdate struct {
+	Description       *string
+	RequiredReviewers *int
+	RequiredApprovals *int
+	SelectionStrategy *domain.SelectionStrategy
+	// LeadUserID sets the team lead; an empty string clears it.
+	LeadUserID *string
+}
+
 type Store struct {
-	db *sqlx.DB
+	db       *sqlx.DB
+	strategy selection.Strategy
 }
 
 func (s *Store) DB() *sqlx.DB {
@@ -34,26 +78,83 @@ func NewStore(dbURL string) (*Store, error) {
 		return nil, err
 	}
 	db.SetConnMaxLifetime(5 * time.Minute)
-	return &Store{db: db}, nil
+	return &Store{db: db, strategy: selection.NewLeastLoaded(time.Now().UnixNano())}, nil
 }
 
-func (s *Store) CreateTeam(ctx context.Context, t *domain.Team) error {
-	_, err := s.db.ExecContext(ctx, `INSERT INTO teams (name, description, created_at) VALUES ($1,$2,now())`, t.Name, t.Description)
+// SetStrategy replaces the reviewer selection strategy used for PR creation
+// and reassignment.
+func (s *Store) SetStrategy(st selection.Strategy) {
+	s.strategy = st
+}
+
+// CreateTeam creates the team together with its members in one transaction,
+// so a member that cannot be added leaves no team behind.
+func (s *Store) CreateTeam(ctx context.Context, t *domain.Team, members []*domain.User) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in CreateTeam: %v", rollbackErr)
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO teams (name, description, required_reviewers, required_approvals, selection_strategy, created_at) VALUES ($1,$2,$3,$4,$5,now())`,
+		t.Name, t.Description, t.RequiredReviewers, t.RequiredApprovals, t.SelectionStrategy)
 	if err != nil {
 		return ErrAlreadyExists
 	}
-	return nil
+	for _, u := range members {
+		if err := upsertUser(ctx, tx, u); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *Store) UpdateTeam(ctx context.Context, name string, upd TeamUpdate) (*domain.Team, error) {
+	if upd.LeadUserID != nil && *upd.LeadUserID != "" {
+		var exists bool
+		if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1)`, *upd.LeadUserID); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrLeadNotFound
+		}
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+        UPDATE teams SET
+            required_reviewers = COALESCE($1, required_reviewers),
+            required_approvals = COALESCE($2, required_approvals),
+            description = COALESCE($3, description),
+            selection_strategy = COALESCE($4, selection_strategy),
+            lead_user_id = CASE WHEN $5::text IS NULL THEN lead_user_id ELSE NULLIF($5, '') END
+        WHERE name = $6
+`, upd.RequiredReviewers, upd.RequiredApprovals, upd.Description, upd.SelectionStrategy, upd.LeadUserID, name)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, ErrNotFound
+	}
+	team, _, err := s.GetTeam(ctx, name)
+	return team, err
 }
 
 func (s *Store) GetTeam(ctx context.Context, name string) (*domain.Team, []domain.User, error) {
 	var team domain.Team
-	err := s.db.GetContext(ctx, &team, `SELECT name, description, created_at FROM teams WHERE name = $1`, name)
+	err := s.db.GetContext(ctx, &team, `SELECT name, description, required_reviewers, required_approvals, selection_strategy, COALESCE(lead_user_id, '') AS lead_user_id, created_at FROM teams WHERE name = $1`, name)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil, ErrNotFound
 		}
 		return nil, nil, err
 	}
+	if team.FallbackTeams, err = teamFallbacks(ctx, s.db, name); err != nil {
+		return nil, nil, err
+	}
 	var members []domain.User
 	err = s.db.SelectContext(ctx, &members, `SELECT user_id, username, is_active, team_name, created_at, updated_at FROM users WHERE team_name = $1`, name)
 	if err != nil {
@@ -62,18 +163,38 @@ func (s *Store) GetTeam(ctx context.Context, name string) (*domain.Team, []domai
 	return &team, members, nil
 }
 
-func (s *Store) UpsertUser(ctx context.Context, u *domain.User) error {
-	_, err := s.db.ExecContext(ctx, `
-       INSERT INTO users (user_id, username, is_active, team_name, created_at, updated_at)
-       VALUES ($1,$2,$3,$4,now(),now())
-       ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active, team_name = EXCLUDED.team_name, updated_at = now()
-`, u.ID, u.Username, u.IsActive, u.TeamName)
+// upsertUser adds the user or updates an existing user's name. Moving an
+// existing user to another team or changing whether they are active goes
+// through MoveUserTeam and SetUserActive, which take care of their open
+// reviews, so here it is refused with a MemberChangeError.
+func upsertUser(ctx context.Context, tx *sqlx.Tx, u *domain.User) error {
+	var cur struct {
+		TeamName string `db:"team_name"`
+		IsActive bool   `db:"is_active"`
+	}
+	err := tx.GetContext(ctx, &cur, `SELECT team_name, is_active FROM users WHERE user_id = $1 FOR UPDATE`, u.ID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		if _, err := tx.ExecContext(ctx, `INSERT INTO users (user_id, username, is_active, team_name, created_at, updated_at) VALUES ($1,$2,$3,$4,now(),now())`,
+			u.ID, u.Username, u.IsActive, u.TeamName); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO user_team_history (user_id, from_team, to_team, moved_at) VALUES ($1, NULL, $2, now())`, u.ID, u.TeamName)
+		return err
+	case err != nil:
+		return err
+	case cur.TeamName != string(u.TeamName):
+		return &MemberChangeError{UserID: string(u.ID), Field: "team_name"}
+	case cur.IsActive != u.IsActive:
+		return &MemberChangeError{UserID: string(u.ID), Field: "is_active"}
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE users SET username = $1, updated_at = now() WHERE user_id = $2`, u.Username, u.ID)
 	return err
 }
 
 func (s *Store) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	var u domain.User
-	err := s.db.GetContext(ctx, &u, `SELECT user_id, username, is_active, team_name, created_at, updated_at FROM users WHERE user_id = $1`, id)
+	err := s.db.GetContext(ctx, &u, `SELECT user_id, username, is_active, team_name, max_open_reviews, created_at, updated_at FROM users WHERE user_id = $1`, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -83,50 +204,175 @@ func (s *Store) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	return &u, nil
 }
 
-func (s *Store) SetUserActive(ctx context.Context, id string, active bool) (*domain.User, error) {
-	_, err := s.db.ExecContext(ctx, `UPDATE users SET is_active = $1, updated_at = now() WHERE user_id = $2`, active, id)
+// SetMaxOpenReviews sets the user's review limit; nil removes it.
+func (s *Store) SetMaxOpenReviews(ctx context.Context, id string, limit *int) (*domain.User, error) {
+	res, err := s.db.ExecContext(ctx, `UPDATE users SET max_open_reviews = $1, updated_at = now() WHERE user_id = $2`, limit, id)
 	if err != nil {
 		return nil, err
 	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, ErrNotFound
+	}
 	return s.GetUser(ctx, id)
 }
 
+// SetUserActive updates the user's active flag. Deactivating a user hands
+// each of their OPEN review slots to another active teammate in the same
+// transaction; the outcome for every slot is returned.
+func (s *Store) SetUserActive(ctx context.Context, id string, active bool) (*domain.User, []Reassignment, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in SetUserActive: %v", rollbackErr)
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, `UPDATE users SET is_active = $1, updated_at = now() WHERE user_id = $2`, active, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, nil, ErrNotFound
+	}
+
+	reassignments := make([]Reassignment, 0)
+	if !active {
+		reassignments, err = s.reassignOpenSlots(ctx, tx, id, eventCause{Kind: domain.EventDeactivationReassign, Reason: reasonDeactivated})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	u, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, reassignments, nil
+}
+
+// reassignOpenSlots reassigns every OPEN PR slot held by the user. PRs without
+// a replacement keep the user and are reported with NoCandidate set.
+func (s *Store) reassignOpenSlots(ctx context.Context, tx *sqlx.Tx, userID string, cause eventCause) ([]Reassignment, error) {
+	var prIDs []string
+	if err := tx.SelectContext(ctx, &prIDs, `
+        SELECT a.pull_request_id FROM pr_assignments a
+        JOIN prs p ON p.pull_request_id = a.pull_request_id
+        WHERE a.user_id = $1 AND p.status = $2
+        ORDER BY a.pull_request_id
+`, userID, domain.StatusOpen); err != nil {
+		return nil, err
+	}
+
+	return s.reassignSlots(ctx, tx, userID, prIDs, cause)
+}
+
+// reassignSlots hands the user's slot on each of the PRs to another
+// candidate, reporting the PRs without a replacement with NoCandidate set.
+func (s *Store) reassignSlots(ctx context.Context, tx *sqlx.Tx, userID string, prIDs []string, cause eventCause) ([]Reassignment, error) {
+	result := make([]Reassignment, 0, len(prIDs))
+	for _, prID := range prIDs {
+		r := Reassignment{PullRequestID: prID, OldUserID: userID}
+		candidate, err := s.reassign(ctx, tx, prID, userID, "", cause)
+		switch {
+		case errors.Is(err, ErrNoCandidate):
+			r.NoCandidate = true
+		case errors.Is(err, ErrCapacityExhausted):
+			r.NoCandidate, r.CapacityExhausted = true, true
+		case err != nil:
+			return nil, err
+		default:
+			r.NewUserID = candidate
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
 func (s *Store) CreatePR(ctx context.Context, pr *domain.PullRequest) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in CreatePR: %v", rollbackErr)
+		}
+	}()
+
 	var exists string
-	err := s.db.GetContext(ctx, &exists, `SELECT pull_request_id FROM prs WHERE pull_request_id = $1`, pr.ID)
+	err = tx.GetContext(ctx, &exists, `SELECT pull_request_id FROM prs WHERE pull_request_id = $1`, pr.ID)
 	if err == nil {
 		return ErrAlreadyExists
 	}
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
-	_, err = s.db.ExecContext(ctx, `INSERT INTO prs (pull_request_id, pull_request_name, author_id, status, created_at) VALUES ($1,$2,$3,$4,now())`,
-		pr.ID, pr.Name, pr.AuthorID, pr.Status)
-	return err
+	if _, err := tx.ExecContext(ctx, `INSERT INTO prs (pull_request_id, pull_request_name, author_id, status, created_at) VALUES ($1,$2,$3,$4,now())`,
+		pr.ID, pr.Name, pr.AuthorID, pr.Status); err != nil {
+		return err
+	}
+	if len(pr.ChangedFiles) > 0 {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_files (pull_request_id, path) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`,
+			pr.ID, pq.Array(pr.ChangedFiles)); err != nil {
+			return err
+		}
+	}
+	if err := saveAuthors(ctx, tx, pr); err != nil {
+		return err
+	}
+	if err := saveReviewerPreferences(ctx, tx, pr); err != nil {
+		return err
+	}
+	if len(pr.Labels) > 0 {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_labels (pull_request_id, label, created_at) SELECT $1, unnest($2::text[]), now() ON CONFLICT DO NOTHING`,
+			pr.ID, pq.Array(pr.Labels)); err != nil {
+			return err
+		}
+	}
+	// drafts get reviewers only once they are published; an OPEN PR is
+	// staffed in the same transaction so a refused assignment leaves no PR
+	if pr.Status == domain.StatusOpen {
+		if err := s.autoAssign(ctx, tx, pr.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (s *Store) GetPR(ctx context.Context, id string) (*domain.PullRequest, error) {
 	var pr domain.PullRequest
-	err := s.db.GetContext(ctx, &pr, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM prs WHERE pull_request_id = $1`, id)
+	err := s.db.GetContext(ctx, &pr, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at FROM prs WHERE pull_request_id = $1`, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, err
 	}
-	var reviewers []string
-	err = s.db.SelectContext(ctx, &reviewers, `SELECT user_id FROM pr_assignments WHERE pull_request_id = $1 ORDER BY slot ASC`, id)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	if err := loadReviewers(ctx, s.db, &pr); err != nil {
+		return nil, err
+	}
+	if pr.Labels, err = prLabels(ctx, s.db, pr.ID); err != nil {
 		return nil, err
 	}
-	pr.AssignedReviewers = make([]domain.UserID, 0, len(reviewers))
-	for _, r := range reviewers {
-		pr.AssignedReviewers = append(pr.AssignedReviewers, domain.UserID(r))
+	if err := loadReviewerPreferences(ctx, s.db, &pr); err != nil {
+		return nil, err
+	}
+	if err := loadCoAuthors(ctx, s.db, &pr); err != nil {
+		return nil, err
 	}
 	return &pr, nil
 }
 
-func (s *Store) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (string, error) {
+// ReassignReviewer hands the old reviewer's slot to newReviewerID, or to a
+// candidate picked by the strategy when newReviewerID is empty. The reason is
+// kept in the PR's assignment history.
+func (s *Store) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, reason string) (string, error) {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return "", err
@@ -137,59 +383,246 @@ func (s *Store) ReassignReviewer(ctx context.Context, prID, oldReviewerID string
 		}
 	}()
 
-	var status string
-	if err := tx.GetContext(ctx, &status, `SELECT status FROM prs WHERE pull_request_id = $1 FOR UPDATE`, prID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", ErrNotFound
-		}
+	if reason == "" {
+		reason = reasonManualReassign
+	}
+	candidate, err := s.reassign(ctx, tx, prID, oldReviewerID, newReviewerID, eventCause{Kind: domain.EventReassigned, Reason: reason})
+	if err != nil {
 		return "", err
 	}
 
-	if status != "OPEN" {
-		return "", ErrPRMerged
+	if err := tx.Commit(); err != nil {
+		return "", err
 	}
+	return candidate, nil
+}
 
-	var slot int
-	if err := tx.GetContext(ctx, &slot, `SELECT slot FROM pr_assignments WHERE pull_request_id = $1 AND user_id = $2`, prID, oldReviewerID); err != nil {
+// reassign hands the old reviewer's slot to another active teammate, or to
+// the chosen user once they pass validateChosen, recording the hand-over as
+// cause says. An empty candidate means the slot was released because the
+// team now requires fewer reviewers.
+func (s *Store) reassign(ctx context.Context, tx *sqlx.Tx, prID, oldReviewerID, chosen string, cause eventCause) (string, error) {
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return "", err
+	}
+	if err := requireOpen(status); err != nil {
+		return "", err
+	}
+
+	var slot struct {
+		Slot int `db:"slot"`
+		slotOwner
+	}
+	if err := tx.GetContext(ctx, &slot, `
+        SELECT a.slot, COALESCE(a.owner_team, '') AS owner_team, COALESCE(a.owner_user, '') AS owner_user,
+            COALESCE(ou.team_name, '') AS owner_user_team
+        FROM pr_assignments a LEFT JOIN users ou ON ou.user_id = a.owner_user
+        WHERE a.pull_request_id = $1 AND a.user_id = $2
+`, prID, oldReviewerID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", ErrReviewerNotAssigned
 		}
 		return "", err
 	}
 
+	required, err := requiredReviewers(ctx, tx, prID)
+	if err != nil {
+		return "", err
+	}
+	if slot.Slot > required && !slot.covered() && chosen == "" {
+		// the team needs fewer reviewers than when this slot was filled,
+		// so the slot is released instead of being handed to someone else
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pr_assignments WHERE pull_request_id = $1 AND slot = $2`, prID, slot.Slot); err != nil {
+			return "", err
+		}
+		if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+			PullRequestID: prID, Kind: domain.EventRemoved, Slot: slot.Slot, UserID: domain.UserID(oldReviewerID), Reason: reasonSlotReleased,
+		}); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+
+	// replacements come from the author's team and its fallbacks, the same
+	// pool the PR was staffed from on creation
 	var teamName string
-	if err := tx.GetContext(ctx, &teamName, `SELECT team_name FROM users WHERE user_id = $1`, oldReviewerID); err != nil {
+	if err := tx.GetContext(ctx, &teamName, `
+        SELECT u.team_name FROM prs p JOIN users u ON u.user_id = p.author_id
+        WHERE p.pull_request_id = $1
+`, prID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", ErrNotFound
 		}
 		return "", err
 	}
 
-	var candidate string
-	if err := tx.GetContext(ctx, &candidate, `
-        SELECT user_id FROM users
-        WHERE team_name = $1 AND is_active = true AND user_id NOT IN (
-            SELECT user_id FROM pr_assignments WHERE pull_request_id = $2
-        ) AND user_id != $3
-        ORDER BY random()
-        LIMIT 1
-`, teamName, prID, oldReviewerID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", ErrNoCandidate
+	if chosen != "" {
+		p, err := validateChosen(ctx, tx, prID, teamName, slot.slotOwner, chosen)
+		if err != nil {
+			return "", err
+		}
+		if err := updateSlot(ctx, tx, prID, slot.Slot, p); err != nil {
+			return "", err
+		}
+		if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+			PullRequestID: prID, Kind: cause.Kind, Slot: slot.Slot, UserID: domain.UserID(p.UserID), PreviousUserID: domain.UserID(oldReviewerID), Reason: cause.Reason,
+		}); err != nil {
+			return "", err
 		}
+		return p.UserID, nil
+	}
+
+	var exclude []string
+	if err := tx.SelectContext(ctx, &exclude, `
+        SELECT user_id FROM pr_assignments WHERE pull_request_id = $1
+        UNION SELECT user_id FROM pr_authors WHERE pull_request_id = $1
+        UNION SELECT user_id FROM pr_reviewer_preferences WHERE pull_request_id = $1 AND kind = $2
+`, prID, preferenceExcluded); err != nil {
+		return "", err
+	}
+	conflicted, err := authorConflicts(ctx, tx, prID)
+	if err != nil {
 		return "", err
 	}
+	exclude = append(exclude, conflicted...)
 
-	if _, err := tx.ExecContext(ctx, `UPDATE pr_assignments SET user_id = $1, assigned_at = now() WHERE pull_request_id = $2 AND slot = $3`, candidate, prID, slot); err != nil {
+	labels, err := prLabels(ctx, tx, prID)
+	if err != nil {
+		return "", err
+	}
+	fallbacks, err := teamFallbacks(ctx, tx, teamName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := lockRotations(ctx, tx, append([]string{teamName, slot.pool()}, fallbacks...)); err != nil {
 		return "", err
 	}
 
-	if err := tx.Commit(); err != nil {
+	// a slot covering an owning team or user stays with that team while it
+	// has someone to offer
+	var picked []pick
+	if pool := slot.pool(); pool != "" {
+		own, _, err := s.pickReviewers(ctx, tx, pool, exclude, labels, 1)
+		if err != nil {
+			return "", err
+		}
+		if len(own) > 0 {
+			picked = []pick{slot.mark(pick{UserID: own[0]})}
+		}
+	}
+	if len(picked) == 0 {
+		var saturated int
+		picked, saturated, err = s.pickWithFallback(ctx, tx, teamName, exclude, labels, 1)
+		if err != nil {
+			return "", err
+		}
+		if len(picked) == 0 {
+			if saturated > 0 {
+				return "", ErrCapacityExhausted
+			}
+			return "", ErrNoCandidate
+		}
+	}
+	candidate := picked[0].UserID
+
+	if err := updateSlot(ctx, tx, prID, slot.Slot, picked[0]); err != nil {
+		return "", err
+	}
+	if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+		PullRequestID: prID, Kind: cause.Kind, Slot: slot.Slot, UserID: domain.UserID(candidate), PreviousUserID: domain.UserID(oldReviewerID), Reason: cause.Reason,
+	}); err != nil {
 		return "", err
 	}
+
 	return candidate, nil
 }
 
+// updateSlot seats the pick on the slot with its fallback and owner markers.
+func updateSlot(ctx context.Context, tx *sqlx.Tx, prID string, slot int, p pick) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE pr_assignments SET user_id = $1, fallback_team = NULLIF($2, ''), owner_team = NULLIF($3, ''),
+            owner_user = NULLIF($4, ''), assigned_at = now()
+        WHERE pull_request_id = $5 AND slot = $6
+`, p.UserID, p.FallbackTeam, p.OwnerTeam, p.OwnerUser, prID, slot)
+	return err
+}
+
+// pickReviewers chooses up to k active members of the team, skipping the
+// excluded users, using the store's selection strategy. Members skilled in
+// any of the labels are preferred. ROUND_ROBIN teams take their members in
+// turn instead, skilled ones still first, and move the team's cursor past
+// the last one picked.
+// Members at their review limit are skipped and counted in saturated.
+func (s *Store) pickReviewers(ctx context.Context, tx *sqlx.Tx, teamName string, exclude, labels []string, k int) (picked []string, saturated int, err error) {
+	last, rotating, err := rotationCursor(ctx, tx, teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+	candidates, err := candidatePool(ctx, tx, teamName, exclude, labels)
+	if err != nil {
+		return nil, 0, err
+	}
+	free, saturated := selection.Available(candidates)
+	if !rotating {
+		return selection.PickSkilled(s.strategy, free, k), saturated, nil
+	}
+
+	picked = selection.PickSkilled(selection.NewRoundRobin(last), free, k)
+	if len(picked) > 0 {
+		if err := advanceRotation(ctx, tx, teamName, picked[len(picked)-1]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return picked, saturated, nil
+}
+
+// candidatePool returns the team members who can take a review right now:
+// active, not absent and not excluded, with their OPEN assignment count,
+// review limit and number of skills among the labels.
+func candidatePool(ctx context.Context, q sqlx.QueryerContext, teamName string, exclude, labels []string) ([]selection.Candidate, error) {
+	if exclude == nil {
+		// a nil array is sent as NULL, and "<> ALL(NULL)" matches nothing
+		exclude = []string{}
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+	var candidates []selection.Candidate
+	err := sqlx.SelectContext(ctx, q, &candidates, `
+        SELECT u.user_id, COUNT(p.pull_request_id) AS open_reviews, u.max_open_reviews,
+            (SELECT COUNT(*) FROM user_skills sk WHERE sk.user_id = u.user_id AND sk.skill = ANY($4)) AS skill_matches
+        FROM users u
+        LEFT JOIN pr_assignments a ON a.user_id = u.user_id
+        LEFT JOIN prs p ON p.pull_request_id = a.pull_request_id AND p.status = $3
+        WHERE u.team_name = $1 AND u.is_active = true AND u.user_id <> ALL($2)
+          AND NOT EXISTS (
+              SELECT 1 FROM user_absences ab
+              WHERE ab.user_id = u.user_id AND ab.starts_at <= now() AND ab.ends_at > now()
+          )
+        GROUP BY u.user_id
+`, teamName, pq.Array(exclude), domain.StatusOpen, pq.Array(labels))
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// requiredReviewers returns how many reviewers the PR author's team asks for.
+func requiredReviewers(ctx context.Context, q sqlx.QueryerContext, prID string) (int, error) {
+	var n int
+	err := sqlx.GetContext(ctx, q, &n, `
+        SELECT t.required_reviewers FROM prs p
+        JOIN users u ON u.user_id = p.author_id
+        JOIN teams t ON t.name = u.team_name
+        WHERE p.pull_request_id = $1
+`, prID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	return n, err
+}
+
 func (s *Store) AssignReviewers(ctx context.Context, prID string, reviewers []string) error {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -201,7 +634,15 @@ func (s *Store) AssignReviewers(ctx context.Context, prID string, reviewers []st
 		}
 	}()
 
-	if _, err := tx.ExecContext(ctx, `DELETE FROM pr_assignments WHERE pull_request_id = $1`, prID); err != nil {
+	required, err := requiredReviewers(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	if len(reviewers) > required {
+		return ErrTooManyReviewers
+	}
+
+	if err := clearAssignments(ctx, tx, prID, reasonManualReplace); err != nil {
 		return err
 	}
 
@@ -210,14 +651,22 @@ func (s *Store) AssignReviewers(ctx context.Context, prID string, reviewers []st
 		if _, err := tx.ExecContext(ctx, `INSERT INTO pr_assignments (pull_request_id, user_id, slot, assigned_at) VALUES ($1,$2,$3,now())`, prID, uid, slot); err != nil {
 			return err
 		}
+		if err := logAssignment(ctx, tx, domain.AssignmentEvent{
+			PullRequestID: prID, Kind: domain.EventAssigned, Slot: slot, UserID: domain.UserID(uid), Reason: reasonManualAssign,
+		}); err != nil {
+			return err
+		}
 	}
 	return tx.Commit()
 }
 
-func (s *Store) SetPRMerged(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// SetPRMerged merges the PR if it satisfies the author team's merge policy.
+// With force the policy is not checked; forced reports whether this call
+// merged the PR without checking it, so it is false for an already merged PR.
+func (s *Store) SetPRMerged(ctx context.Context, prID string, force bool) (pr *domain.PullRequest, forced bool, err error) {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer func() {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
@@ -225,47 +674,70 @@ func (s *Store) SetPRMerged(ctx context.Context, prID string) (*domain.PullReque
 		}
 	}()
 
-	var status string
-	if err := tx.GetContext(ctx, &status, `SELECT status FROM prs WHERE pull_request_id = $1 FOR UPDATE`, prID); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, err
+	status, err := lockPRStatus(ctx, tx, prID)
+	if err != nil {
+		return nil, false, err
 	}
 
-	if status == "MERGED" {
+	if status == domain.StatusMerged {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
 			log.Printf("warning: rollback failed in SetPRMerged (already merged): %v", rollbackErr)
 		}
-		return s.GetPR(ctx, prID)
+		pr, err = s.GetPR(ctx, prID)
+		return pr, false, err
 	}
 
-	if _, err := tx.ExecContext(ctx, `UPDATE prs SET status='MERGED', merged_at = now() WHERE pull_request_id = $1`, prID); err != nil {
-		return nil, err
+	if !status.CanTransitionTo(domain.StatusMerged) {
+		return nil, false, domain.ErrInvalidTransition
+	}
+
+	if !force {
+		var policy domain.MergePolicy
+		if err := tx.GetContext(ctx, &policy.RequiredApprovals, `
+            SELECT t.required_approvals FROM prs p
+            JOIN users u ON u.user_id = p.author_id
+            JOIN teams t ON t.name = u.team_name
+            WHERE p.pull_request_id = $1
+`, prID); err != nil {
+			return nil, false, err
+		}
+		reviews, err := assignedReviews(ctx, tx, prID)
+		if err != nil {
+			return nil, false, err
+		}
+		if unmet := policy.Evaluate(reviews); len(unmet) > 0 {
+			return nil, false, &MergeBlockedError{Unmet: unmet}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE prs SET status = $1, merged_at = now() WHERE pull_request_id = $2`, domain.StatusMerged, prID); err != nil {
+		return nil, false, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return s.GetPR(ctx, prID)
+	pr, err = s.GetPR(ctx, prID)
+	return pr, force, err
 }
 
 func (s *Store) GetPRsByReviewer(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	var prs []domain.PullRequest
-	err := s.db.SelectContext(ctx, &prs, `SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at
+	err := s.db.SelectContext(ctx, &prs, `SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.created_at, p.merged_at, p.closed_at
        FROM prs p JOIN pr_assignments a ON p.pull_request_id = a.pull_request_id
-       WHERE a.user_id = $1 AND p.status = 'OPEN'`, userID)
+       WHERE a.user_id = $1 AND p.status = $2`, userID, domain.StatusOpen)
 	if err != nil {
 		return nil, err
 	}
 	for i := range prs {
-		var revs []string
-		if err := s.db.SelectContext(ctx, &revs, `SELECT user_id FROM pr_assignments WHERE pull_request_id = $1 ORDER BY slot`, prs[i].ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		if err := loadReviewers(ctx, s.db, &prs[i]); err != nil {
 			return nil, err
 		}
-		prs[i].AssignedReviewers = make([]domain.UserID, 0, len(revs))
-		for _, r := range revs {
-			prs[i].AssignedReviewers = append(prs[i].AssignedReviewers, domain.UserID(r))
+		if prs[i].Labels, err = prLabels(ctx, s.db, prs[i].ID); err != nil {
+			return nil, err
+		}
+		if err := loadCoAuthors(ctx, s.db, &prs[i]); err != nil {
+			return nil, err
 		}
 	}
 	return prs, nil
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+var (
+	ErrTeamHasOpenPRs = errors.New("team has open prs")
+	ErrTeamNotEmpty   = errors.New("team not empty")
+	ErrTeamOwnsPaths  = errors.New("team owns paths")
+	ErrLeadNotFound   = errors.New("lead not found")
+)
+
+// RenameTeam changes the team name; users follow through ON UPDATE CASCADE
+// and ownership rules naming the team as an owner are rewritten.
+func (s *Store) RenameTeam(ctx context.Context, name, newName string) (*domain.Team, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in RenameTeam: %v", rollbackErr)
+		}
+	}()
+
+	var taken bool
+	if err := tx.GetContext(ctx, &taken, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, newName); err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrAlreadyExists
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE teams SET name = $1 WHERE name = $2`, newName, name)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, ErrNotFound
+	}
+	// owner_teams is a plain array, so it does not follow the cascade
+	if _, err := tx.ExecContext(ctx, `UPDATE ownership_rules SET owner_teams = array_replace(owner_teams, $2, $1) WHERE $2 = ANY(owner_teams)`,
+		newName, name); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	team, _, err := s.GetTeam(ctx, newName)
+	return team, err
+}
+
+// DeleteTeam removes the team. Members are moved to moveTo when it is set,
+// each recorded like a team move and handing their OPEN review slots on
+// first when reassign is set; otherwise the team must have no members, and
+// therefore no open PRs. Another team's ownership rules must not name it as
+// an owner. It returns the number of moved members and the reassigned slots.
+func (s *Store) DeleteTeam(ctx context.Context, name, moveTo string, reassign bool) (int, []Reassignment, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("warning: rollback failed in DeleteTeam: %v", rollbackErr)
+		}
+	}()
+
+	var locked string
+	if err := tx.GetContext(ctx, &locked, `SELECT name FROM teams WHERE name = $1 FOR UPDATE`, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil, ErrNotFound
+		}
+		return 0, nil, err
+	}
+
+	// a rule needs at least one owner, so rules naming the team are left
+	// for their owners to edit instead of being rewritten here
+	var owning bool
+	if err := tx.GetContext(ctx, &owning, `SELECT EXISTS (SELECT 1 FROM ownership_rules WHERE $1 = ANY(owner_teams) AND team_name <> $1)`, name); err != nil {
+		return 0, nil, err
+	}
+	if owning {
+		return 0, nil, ErrTeamOwnsPaths
+	}
+
+	if moveTo == "" {
+		var openPRs int
+		if err := tx.GetContext(ctx, &openPRs, `
+            SELECT COUNT(*) FROM prs p JOIN users u ON u.user_id = p.author_id
+            WHERE u.team_name = $1 AND p.status IN ($2, $3)
+`, name, domain.StatusOpen, domain.StatusDraft); err != nil {
+			return 0, nil, err
+		}
+		if openPRs > 0 {
+			return 0, nil, ErrTeamHasOpenPRs
+		}
+		var members int
+		if err := tx.GetContext(ctx, &members, `SELECT COUNT(*) FROM users WHERE team_name = $1`, name); err != nil {
+			return 0, nil, err
+		}
+		if members > 0 {
+			return 0, nil, ErrTeamNotEmpty
+		}
+	}
+
+	moved, reassignments := 0, make([]Reassignment, 0)
+	if moveTo != "" {
+		if err := tx.GetContext(ctx, &locked, `SELECT name FROM teams WHERE name = $1 FOR UPDATE`, moveTo); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, nil, ErrNotFound
+			}
+			return 0, nil, err
+		}
+		var members []string
+		if err := tx.SelectContext(ctx, &members, `SELECT user_id FROM users WHERE team_name = $1 ORDER BY user_id FOR UPDATE`, name); err != nil {
+			return 0, nil, err
+		}
+		for _, userID := range members {
+			r, err := s.moveUser(ctx, tx, userID, name, moveTo, reassign)
+			if err != nil {
+				return 0, nil, err
+			}
+			reassignments = append(reassignments, r...)
+		}
+		moved = len(members)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM teams WHERE name = $1`, name); err != nil {
+		return 0, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, err
+	}
+	return moved, reassignments, nil
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// TeamWorkload returns the review load of every member of the team as of
+// now, busiest first, in a single aggregate query.
+func (s *Store) TeamWorkload(ctx context.Context, teamName string, now time.Time) ([]domain.Workload, error) {
+	var exists bool
+	if err := s.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, teamName); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	workload := make([]domain.Workload, 0)
+	if err := s.db.SelectContext(ctx, &workload, `
+        WITH open_slots AS (
+            SELECT a.user_id, a.assigned_at,
+                EXISTS (
+                    SELECT 1 FROM pr_reviews r
+                    WHERE r.pull_request_id = a.pull_request_id AND r.user_id = a.user_id AND r.created_at >= a.assigned_at
+                ) AS reviewed
+            FROM pr_assignments a
+            JOIN prs p ON p.pull_request_id = a.pull_request_id
+            JOIN users u ON u.user_id = a.user_id
+            WHERE u.team_name = $1 AND p.status = $2
+        ), open_load AS (
+            SELECT user_id, COUNT(*) AS open_reviews,
+                COUNT(*) FILTER (WHERE NOT reviewed) AS pending_reviews,
+                MIN(assigned_at) FILTER (WHERE NOT reviewed) AS oldest_pending_at
+            FROM open_slots
+            GROUP BY user_id
+        ), done AS (
+            SELECT r.user_id,
+                COUNT(DISTINCT r.pull_request_id) FILTER (WHERE r.created_at >= $3::timestamptz - interval '7 days') AS reviewed_7d,
+                COUNT(DISTINCT r.pull_request_id) AS reviewed_30d
+            FROM pr_reviews r
+            JOIN users u ON u.user_id = r.user_id
+            WHERE u.team_name = $1 AND r.verdict <> $4
+              AND r.created_at >= $3::timestamptz - interval '30 days' AND r.created_at <= $3
+            GROUP BY r.user_id
+        )
+        SELECT u.user_id, u.username, u.is_active, u.max_open_reviews,
+            COALESCE(o.open_reviews, 0) AS open_reviews,
+            COALESCE(o.pending_reviews, 0) AS pending_reviews,
+            o.oldest_pending_at,
+            COALESCE(d.reviewed_7d, 0) AS reviewed_7d,
+            COALESCE(d.reviewed_30d, 0) AS reviewed_30d
+        FROM users u
+        LEFT JOIN open_load o ON o.user_id = u.user_id
+        LEFT JOIN done d ON d.user_id = u.user_id
+        WHERE u.team_name = $1
+        ORDER BY open_reviews DESC, o.oldest_pending_at NULLS LAST, u.user_id
+`, teamName, domain.StatusOpen, now, domain.VerdictCommented); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
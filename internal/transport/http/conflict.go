@@ -0,0 +1,122 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+func (h *Handler) HandleAddConflict(c *gin.Context) {
+	var req struct {
+		UserID      string `json:"user_id"`
+		OtherUserID string `json:"other_user_id"`
+		Reason      string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	conflict, err := domain.NewConflict(domain.UserID(req.UserID), domain.UserID(req.OtherUserID), req.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "user_id and other_user_id must be two different users"},
+		})
+		return
+	}
+
+	saved, err := h.store.CreateConflict(c.Request.Context(), conflict)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "user not found"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "CONFLICT_EXISTS", "message": "conflict between these users already exists"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"conflict": saved})
+}
+
+func (h *Handler) HandleRemoveConflict(c *gin.Context) {
+	var req struct {
+		UserID      string `json:"user_id"`
+		OtherUserID string `json:"other_user_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	conflict, err := domain.NewConflict(domain.UserID(req.UserID), domain.UserID(req.OtherUserID), "")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "user_id and other_user_id must be two different users"},
+		})
+		return
+	}
+
+	if err := h.store.DeleteConflict(c.Request.Context(), conflict); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "conflict not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":       req.UserID,
+		"other_user_id": req.OtherUserID,
+	})
+}
+
+func (h *Handler) HandleListConflicts(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "user_id required"},
+		})
+		return
+	}
+
+	conflicts, err := h.store.ListConflicts(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "user not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":   userID,
+		"conflicts": conflicts,
+	})
+}
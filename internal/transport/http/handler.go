@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
@@ -22,8 +23,12 @@ type TeamMemberDTO struct {
 }
 
 type TeamDTO struct {
-	TeamName string          `json:"team_name"`
-	Members  []TeamMemberDTO `json:"members"`
+	TeamName          string          `json:"team_name"`
+	Description       string          `json:"description,omitempty"`
+	RequiredReviewers *int            `json:"required_reviewers,omitempty"`
+	RequiredApprovals *int            `json:"required_approvals,omitempty"`
+	SelectionStrategy string          `json:"selection_strategy,omitempty"`
+	Members           []TeamMemberDTO `json:"members"`
 }
 
 func (h *Handler) HandleTeamAdd(c *gin.Context) {
@@ -38,7 +43,68 @@ func (h *Handler) HandleTeamAdd(c *gin.Context) {
 		return
 	}
 
-	if err := h.store.CreateTeam(c.Request.Context(), &domain.Team{Name: req.TeamName}); err != nil {
+	team := domain.NewTeam(req.TeamName)
+	team.Description = req.Description
+	if req.RequiredReviewers != nil {
+		if err := domain.ValidateRequiredReviewers(*req.RequiredReviewers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+		team.RequiredReviewers = *req.RequiredReviewers
+	}
+	if req.RequiredApprovals != nil {
+		if err := domain.ValidateRequiredApprovals(*req.RequiredApprovals); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+		team.RequiredApprovals = *req.RequiredApprovals
+	}
+	if req.SelectionStrategy != "" {
+		team.SelectionStrategy = domain.SelectionStrategy(req.SelectionStrategy)
+		if err := team.SelectionStrategy.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+	req.RequiredReviewers = &team.RequiredReviewers
+	req.RequiredApprovals = &team.RequiredApprovals
+	req.SelectionStrategy = string(team.SelectionStrategy)
+
+	members := make([]*domain.User, 0, len(req.Members))
+	for _, m := range req.Members {
+		members = append(members, domain.NewUser(m.UserID, m.Username, domain.TeamID(req.TeamName), m.IsActive))
+	}
+
+	if err := h.store.CreateTeam(c.Request.Context(), team, members); err != nil {
+		var change *store.MemberChangeError
+		if errors.As(err, &change) {
+			message := "user " + change.UserID + " belongs to another team; use /users/moveTeam"
+			if change.Field == "is_active" {
+				message = "user " + change.UserID + " has a different is_active; use /users/setIsActive"
+			}
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "MEMBER_CHANGE",
+					"message": message,
+				},
+			})
+			return
+		}
 		if err == store.ErrAlreadyExists {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": gin.H{
@@ -57,20 +123,6 @@ func (h *Handler) HandleTeamAdd(c *gin.Context) {
 		return
 	}
 
-	// upsert members
-	for _, m := range req.Members {
-		u := domain.NewUser(m.UserID, m.Username, domain.TeamID(req.TeamName), m.IsActive)
-		if createErr := h.store.UpsertUser(c.Request.Context(), u); createErr != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": gin.H{
-					"code":    "INTERNAL",
-					"message": createErr.Error(),
-				},
-			})
-			return
-		}
-	}
-
 	c.JSON(http.StatusCreated, gin.H{"team": req})
 }
 
@@ -116,8 +168,407 @@ func (h *Handler) HandleTeamGet(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"team_name": team.Name,
-		"members":   respMembers,
+		"team_name":          team.Name,
+		"description":        team.Description,
+		"required_reviewers": team.RequiredReviewers,
+		"required_approvals": team.RequiredApprovals,
+		"selection_strategy": team.SelectionStrategy,
+		"lead_user_id":       team.LeadUserID,
+		"fallback_teams":     team.FallbackTeams,
+		"members":            respMembers,
+	})
+}
+
+func (h *Handler) HandleTeamUpdate(c *gin.Context) {
+	var req struct {
+		TeamName          string  `json:"team_name"`
+		Description       *string `json:"description"`
+		RequiredReviewers *int    `json:"required_reviewers"`
+		RequiredApprovals *int    `json:"required_approvals"`
+		SelectionStrategy *string `json:"selection_strategy"`
+		LeadUserID        *string `json:"lead_user_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	if req.RequiredReviewers != nil {
+		if err := domain.ValidateRequiredReviewers(*req.RequiredReviewers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	if req.RequiredApprovals != nil {
+		if err := domain.ValidateRequiredApprovals(*req.RequiredApprovals); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+	}
+
+	var strategy *domain.SelectionStrategy
+	if req.SelectionStrategy != nil {
+		st := domain.SelectionStrategy(*req.SelectionStrategy)
+		if err := st.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": err.Error(),
+				},
+			})
+			return
+		}
+		strategy = &st
+	}
+
+	team, err := h.store.UpdateTeam(c.Request.Context(), req.TeamName, store.TeamUpdate{
+		Description:       req.Description,
+		RequiredReviewers: req.RequiredReviewers,
+		RequiredApprovals: req.RequiredApprovals,
+		SelectionStrategy: strategy,
+		LeadUserID:        req.LeadUserID,
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrLeadNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "lead user not found",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team": gin.H{
+			"team_name":          team.Name,
+			"description":        team.Description,
+			"required_reviewers": team.RequiredReviewers,
+			"required_approvals": team.RequiredApprovals,
+			"selection_strategy": team.SelectionStrategy,
+			"lead_user_id":       team.LeadUserID,
+		},
+	})
+}
+
+func (h *Handler) HandleTeamSetFallbacks(c *gin.Context) {
+	var req struct {
+		TeamName      string   `json:"team_name"`
+		FallbackTeams []string `json:"fallback_teams"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	seen := map[string]bool{req.TeamName: true}
+	for _, fb := range req.FallbackTeams {
+		if seen[fb] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "BAD_REQUEST",
+					"message": "fallback_teams must be unique and must not contain the team itself",
+				},
+			})
+			return
+		}
+		seen[fb] = true
+	}
+
+	if err := h.store.SetTeamFallbacks(c.Request.Context(), req.TeamName, req.FallbackTeams); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "team or fallback team not found",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	fallbacks := req.FallbackTeams
+	if fallbacks == nil {
+		fallbacks = make([]string, 0)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"team_name":      req.TeamName,
+		"fallback_teams": fallbacks,
+	})
+}
+
+func (h *Handler) HandleTeamRename(c *gin.Context) {
+	var req struct {
+		TeamName    string `json:"team_name"`
+		NewTeamName string `json:"new_team_name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	if req.TeamName == "" || req.NewTeamName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "team_name and new_team_name required",
+			},
+		})
+		return
+	}
+
+	team, err := h.store.RenameTeam(c.Request.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "TEAM_EXISTS",
+					"message": "new_team_name already exists",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team": gin.H{
+			"team_name":   team.Name,
+			"description": team.Description,
+		},
+		"previous_team_name": req.TeamName,
+	})
+}
+
+func (h *Handler) HandleTeamDelete(c *gin.Context) {
+	var req struct {
+		TeamName      string `json:"team_name"`
+		MoveMembersTo string `json:"move_members_to"`
+		OpenReviews   string `json:"open_reviews"` // KEEP (default) or REASSIGN
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	if req.TeamName == "" || req.TeamName == req.MoveMembersTo {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "team_name required and must differ from move_members_to",
+			},
+		})
+		return
+	}
+
+	mode := strings.ToUpper(req.OpenReviews)
+	if mode == "" {
+		mode = openReviewsKeep
+	}
+	if mode != openReviewsKeep && mode != openReviewsReassign {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "open_reviews must be KEEP or REASSIGN",
+			},
+		})
+		return
+	}
+
+	moved, reassignments, err := h.store.DeleteTeam(c.Request.Context(), req.TeamName, req.MoveMembersTo, mode == openReviewsReassign)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "team or target team not found",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrTeamHasOpenPRs) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "TEAM_HAS_OPEN_PRS",
+					"message": "team owns open PRs; pass move_members_to to move its members",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrTeamOwnsPaths) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "TEAM_OWNS_PATHS",
+					"message": "ownership rules of other teams name this team as an owner",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrTeamNotEmpty) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "TEAM_NOT_EMPTY",
+					"message": "team has members; pass move_members_to to move them",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_name":       req.TeamName,
+		"moved_members":   moved,
+		"move_members_to": req.MoveMembersTo,
+		"open_reviews":    mode,
+		"reassignments":   reassignments,
+	})
+}
+
+func (h *Handler) HandleTeamDeactivateUsers(c *gin.Context) {
+	var req struct {
+		TeamName string   `json:"team_name"`
+		UserIDs  []string `json:"user_ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+	if req.TeamName == "" || len(req.UserIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "team_name and user_ids required",
+			},
+		})
+		return
+	}
+
+	reassignments, err := h.store.DeactivateTeamUsers(c.Request.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrUserNotInTeam) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": gin.H{
+					"code":    "NOT_IN_TEAM",
+					"message": "every user must be a member of the team",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	summary := make([]gin.H, 0, len(reassignments))
+	for _, r := range reassignments {
+		var newUser interface{}
+		if r.NewUserID != "" {
+			newUser = r.NewUserID
+		}
+		summary = append(summary, gin.H{
+			"pull_request_id": r.PullRequestID,
+			"old_user_id":     r.OldUserID,
+			"new_user_id":     newUser,
+			"result":          r.Outcome(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_name":         req.TeamName,
+		"deactivated_users": req.UserIDs,
+		"pull_requests":     summary,
 	})
 }
 
@@ -136,7 +587,7 @@ func (h *Handler) HandleSetIsActive(c *gin.Context) {
 		return
 	}
 
-	u, err := h.store.SetUserActive(c.Request.Context(), req.UserID, req.IsActive)
+	u, reassignments, err := h.store.SetUserActive(c.Request.Context(), req.UserID, req.IsActive)
 	if err != nil {
 		if err == store.ErrNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -156,6 +607,16 @@ func (h *Handler) HandleSetIsActive(c *gin.Context) {
 		return
 	}
 
+	reassigned := make([]store.Reassignment, 0, len(reassignments))
+	noCandidate := make([]string, 0)
+	for _, r := range reassignments {
+		if r.NoCandidate {
+			noCandidate = append(noCandidate, r.PullRequestID)
+			continue
+		}
+		reassigned = append(reassigned, r)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"user": gin.H{
 			"user_id":   u.ID,
@@ -163,14 +624,15 @@ func (h *Handler) HandleSetIsActive(c *gin.Context) {
 			"team_name": u.TeamName,
 			"is_active": u.IsActive,
 		},
+		"reassigned":   reassigned,
+		"no_candidate": noCandidate,
 	})
 }
 
-func (h *Handler) HandleCreatePR(c *gin.Context) {
+func (h *Handler) HandleSetMaxOpenReviews(c *gin.Context) {
 	var req struct {
-		PRID   string `json:"pull_request_id"`
-		Name   string `json:"pull_request_name"`
-		Author string `json:"author_id"`
+		UserID         string `json:"user_id"`
+		MaxOpenReviews *int   `json:"max_open_reviews"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -181,14 +643,23 @@ func (h *Handler) HandleCreatePR(c *gin.Context) {
 		})
 		return
 	}
+	if err := domain.ValidateMaxOpenReviews(req.MaxOpenReviews); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
 
-	author, err := h.store.GetUser(c.Request.Context(), req.Author)
+	u, err := h.store.SetMaxOpenReviews(c.Request.Context(), req.UserID, req.MaxOpenReviews)
 	if err != nil {
-		if err == store.ErrNotFound {
+		if errors.Is(err, store.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{
 					"code":    "NOT_FOUND",
-					"message": "author not found",
+					"message": "user not found",
 				},
 			})
 			return
@@ -202,14 +673,45 @@ func (h *Handler) HandleCreatePR(c *gin.Context) {
 		return
 	}
 
-	pr := domain.NewPR(req.PRID, req.Name, domain.UserID(req.Author))
+	c.JSON(http.StatusOK, gin.H{
+		"user": gin.H{
+			"user_id":          u.ID,
+			"username":         u.Username,
+			"team_name":        u.TeamName,
+			"is_active":        u.IsActive,
+			"max_open_reviews": u.MaxOpenReviews,
+		},
+	})
+}
 
-	if createErr := h.store.CreatePR(c.Request.Context(), pr); createErr != nil {
-		if createErr == store.ErrAlreadyExists {
-			c.JSON(http.StatusConflict, gin.H{
+func (h *Handler) HandleCreatePR(c *gin.Context) {
+	var req struct {
+		PRID         string   `json:"pull_request_id"`
+		Name         string   `json:"pull_request_name"`
+		Author       string   `json:"author_id"`
+		Draft        bool     `json:"draft"`
+		ChangedFiles []string `json:"changed_files"`
+		Labels       []string `json:"labels"`
+		Required     []string `json:"required_reviewers"`
+		Excluded     []string `json:"excluded_reviewers"`
+		CoAuthors    []string `json:"co_authors"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if _, err := h.store.GetUser(c.Request.Context(), req.Author); err != nil {
+		if err == store.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{
-					"code":    "PR_EXISTS",
-					"message": "PR id already exists",
+					"code":    "NOT_FOUND",
+					"message": "author not found",
 				},
 			})
 			return
@@ -217,38 +719,119 @@ func (h *Handler) HandleCreatePR(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": gin.H{
 				"code":    "INTERNAL",
-				"message": createErr.Error(),
+				"message": err.Error(),
 			},
 		})
 		return
 	}
 
-	var candidates []string
-	selectErr := h.store.DB().SelectContext(c.Request.Context(), &candidates,
-		`SELECT user_id FROM users WHERE team_name = $1 AND is_active = true AND user_id <> $2`,
-		author.TeamName, req.Author,
-	)
-	if selectErr != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	pr := domain.NewPR(req.PRID, req.Name, domain.UserID(req.Author))
+	if req.Draft {
+		pr = domain.NewDraftPR(req.PRID, req.Name, domain.UserID(req.Author))
+	}
+	pr.ChangedFiles = req.ChangedFiles
+
+	coAuthors := make([]domain.UserID, 0, len(req.CoAuthors))
+	for _, uid := range req.CoAuthors {
+		coAuthors = append(coAuthors, domain.UserID(uid))
+	}
+	if err := pr.SetCoAuthors(coAuthors); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"error": gin.H{
-				"code":    "INTERNAL",
-				"message": selectErr.Error(),
+				"code":    "BAD_REQUEST",
+				"message": "co_authors must be distinct users other than the author",
+			},
+		})
+		return
+	}
+
+	labels, err := domain.NormalizeTags(req.Labels)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "labels must be non-empty words of up to 50 characters",
 			},
 		})
 		return
 	}
+	pr.Labels = labels
 
-	selected := pickRandomUpTo(candidates, 2)
-	if len(selected) > 0 {
-		if assignErr := h.store.AssignReviewers(c.Request.Context(), pr.ID, selected); assignErr != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
+	for _, uid := range req.Required {
+		pr.PinnedReviewers = append(pr.PinnedReviewers, domain.UserID(uid))
+	}
+	for _, uid := range req.Excluded {
+		pr.ExcludedReviewers = append(pr.ExcludedReviewers, domain.UserID(uid))
+	}
+	if err := domain.ValidateReviewerChoice(pr.Authors(), pr.PinnedReviewers, pr.ExcludedReviewers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"code":    "BAD_REQUEST",
+				"message": "required and excluded reviewers must be distinct users other than the authors",
+			},
+		})
+		return
+	}
+
+	if createErr := h.store.CreatePR(c.Request.Context(), pr); createErr != nil {
+		if createErr == store.ErrAlreadyExists {
+			c.JSON(http.StatusConflict, gin.H{
 				"error": gin.H{
-					"code":    "INTERNAL",
-					"message": assignErr.Error(),
+					"code":    "PR_EXISTS",
+					"message": "PR id already exists",
 				},
 			})
 			return
 		}
+		if errors.Is(createErr, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{
+					"code":    "NOT_FOUND",
+					"message": "co-author or required or excluded reviewer not found",
+				},
+			})
+			return
+		}
+		if errors.Is(createErr, store.ErrReviewerInactive) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "REVIEWER_INACTIVE",
+					"message": "required reviewer is not active",
+				},
+			})
+			return
+		}
+		if errors.Is(createErr, store.ErrConflictOfInterest) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "CONFLICT_OF_INTEREST",
+					"message": "required reviewer has a conflict of interest with the author",
+				},
+			})
+			return
+		}
+		if errors.Is(createErr, store.ErrCapacityExhausted) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "CAPACITY_EXHAUSTED", "message": "every reviewer candidate is at their review limit"},
+			})
+			return
+		}
+		if errors.Is(createErr, store.ErrTooManyReviewers) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "TOO_MANY_REVIEWERS",
+					"message": "more required reviewers than the team's review slots",
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{
+				"code":    "INTERNAL",
+				"message": createErr.Error(),
+			},
+		})
+		return
 	}
 
 	created, getErr := h.store.GetPR(c.Request.Context(), pr.ID)
@@ -272,26 +855,23 @@ func (h *Handler) HandleCreatePR(c *gin.Context) {
 			"pull_request_id":    created.ID,
 			"pull_request_name":  created.Name,
 			"author_id":          created.AuthorID,
+			"co_authors":         created.CoAuthors,
 			"status":             created.Status,
 			"assigned_reviewers": ar,
+			"fallback_reviewers": created.FallbackReviewers,
+			"reviews":            created.Reviews,
+			"labels":             created.Labels,
+			"required_reviewers": created.PinnedReviewers,
+			"excluded_reviewers": created.ExcludedReviewers,
 			"createdAt":          created.CreatedAt,
 		},
 	})
 }
 
-func pickRandomUpTo(candidates []string, k int) []string {
-	if len(candidates) == 0 {
-		return nil
-	}
-	if len(candidates) <= k {
-		return candidates
-	}
-	return candidates[:k]
-}
-
 func (h *Handler) HandleMergePR(c *gin.Context) {
 	var req struct {
-		PRID string `json:"pull_request_id"`
+		PRID  string `json:"pull_request_id"`
+		Force bool   `json:"force"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -303,8 +883,38 @@ func (h *Handler) HandleMergePR(c *gin.Context) {
 		return
 	}
 
-	pr, err := h.store.SetPRMerged(c.Request.Context(), req.PRID)
+	if req.Force && !h.isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": gin.H{
+				"code":    "FORBIDDEN",
+				"message": "force merge requires the admin token",
+			},
+		})
+		return
+	}
+
+	pr, forced, err := h.store.SetPRMerged(c.Request.Context(), req.PRID, req.Force)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "INVALID_TRANSITION",
+					"message": "only OPEN PRs can be merged",
+				},
+			})
+			return
+		}
+		var blocked *store.MergeBlockedError
+		if errors.As(err, &blocked) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "MERGE_BLOCKED",
+					"message": "merge policy is not satisfied",
+					"unmet":   blocked.Unmet,
+				},
+			})
+			return
+		}
 		if err == store.ErrNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{
@@ -333,10 +943,15 @@ func (h *Handler) HandleMergePR(c *gin.Context) {
 			"pull_request_id":    pr.ID,
 			"pull_request_name":  pr.Name,
 			"author_id":          pr.AuthorID,
+			"co_authors":         pr.CoAuthors,
 			"status":             pr.Status,
 			"assigned_reviewers": ar,
+			"fallback_reviewers": pr.FallbackReviewers,
+			"reviews":            pr.Reviews,
+			"labels":             pr.Labels,
 			"mergedAt":           pr.MergedAt,
 		},
+		"forced": forced,
 	})
 }
 
@@ -344,6 +959,8 @@ func (h *Handler) HandleReassign(c *gin.Context) {
 	var req struct {
 		PRID    string `json:"pull_request_id"`
 		OldUser string `json:"old_user_id"`
+		NewUser string `json:"new_user_id"`
+		Reason  string `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -352,17 +969,30 @@ func (h *Handler) HandleReassign(c *gin.Context) {
 		return
 	}
 
-	candidate, err := h.store.ReassignReviewer(c.Request.Context(), req.PRID, req.OldUser)
+	candidate, err := h.store.ReassignReviewer(c.Request.Context(), req.PRID, req.OldUser, req.NewUser, req.Reason)
 	if err != nil {
+		var invalid *store.InvalidCandidateError
+		if errors.As(err, &invalid) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "INVALID_CANDIDATE", "message": "new_user_id cannot take the slot", "check": invalid.Check},
+			})
+			return
+		}
 		if errors.Is(err, store.ErrReviewerNotAssigned) {
 			c.JSON(http.StatusConflict, gin.H{
 				"error": gin.H{"code": "NOT_ASSIGNED", "message": "reviewer is not assigned to this PR"},
 			})
 			return
 		}
+		if errors.Is(err, store.ErrCapacityExhausted) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "CAPACITY_EXHAUSTED", "message": "every replacement candidate is at their review limit"},
+			})
+			return
+		}
 		if errors.Is(err, store.ErrNoCandidate) {
 			c.JSON(http.StatusConflict, gin.H{
-				"error": gin.H{"code": "NO_CANDIDATE", "message": "no active replacement candidate in team"},
+				"error": gin.H{"code": "NO_CANDIDATE", "message": "no active replacement candidate in team or its fallback teams"},
 			})
 			return
 		}
@@ -372,6 +1002,12 @@ func (h *Handler) HandleReassign(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, store.ErrPRNotOpen) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_NOT_OPEN", "message": "cannot reassign on draft or closed PR"},
+			})
+			return
+		}
 		if errors.Is(err, store.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": gin.H{"code": "NOT_FOUND", "message": "pr or user not found"},
@@ -400,15 +1036,26 @@ func (h *Handler) HandleReassign(c *gin.Context) {
 		ar = append(ar, string(r))
 	}
 
+	// an empty candidate means the slot was released because the team now
+	// requires fewer reviewers
+	var replacedBy interface{}
+	if candidate != "" {
+		replacedBy = candidate
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"pr": gin.H{
 			"pull_request_id":    updated.ID,
 			"pull_request_name":  updated.Name,
 			"author_id":          updated.AuthorID,
+			"co_authors":         updated.CoAuthors,
 			"status":             updated.Status,
 			"assigned_reviewers": ar,
+			"fallback_reviewers": updated.FallbackReviewers,
+			"reviews":            updated.Reviews,
+			"labels":             updated.Labels,
 		},
-		"replaced_by": candidate,
+		"replaced_by": replacedBy,
 	})
 }
 
@@ -441,7 +1088,10 @@ func (h *Handler) HandleGetReview(c *gin.Context) {
 			"pull_request_id":   p.ID,
 			"pull_request_name": p.Name,
 			"author_id":         p.AuthorID,
+			"co_authors":        p.CoAuthors,
 			"status":            p.Status,
+			"reviews":           p.Reviews,
+			"labels":            p.Labels,
 		})
 	}
 
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// actorHeader names the user or system making a request; assignment history
+// records it as the actor of the changes.
+const actorHeader = "X-Actor"
+
+// adminTokenHeader carries the token that authorizes admin-only requests.
+const adminTokenHeader = "X-Admin-Token"
+
+// isAdmin reports whether the request carries the configured admin token.
+// Without a configured token nobody is an admin.
+func (h *Handler) isAdmin(c *gin.Context) bool {
+	token := c.GetHeader(adminTokenHeader)
+	return h.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+func actorMiddleware(c *gin.Context) {
+	if actor := c.GetHeader(actorHeader); actor != "" {
+		c.Request = c.Request.WithContext(store.WithActor(c.Request.Context(), actor))
+	}
+	c.Next()
+}
+
+// HandlePRHistory returns the timeline of reviewers put on and taken off the PR.
+func (h *Handler) HandlePRHistory(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "pull_request_id required"},
+		})
+		return
+	}
+
+	events, err := h.store.PRHistory(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"events":          events,
+	})
+}
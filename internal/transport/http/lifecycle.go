@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// HandlePublishPR moves a DRAFT PR to OPEN and assigns reviewers.
+func (h *Handler) HandlePublishPR(c *gin.Context) {
+	h.transitionPR(c, domain.StatusDraft, domain.StatusOpen)
+}
+
+// HandleClosePR closes an OPEN PR without merging and releases its reviewers.
+func (h *Handler) HandleClosePR(c *gin.Context) {
+	h.transitionPR(c, domain.StatusOpen, domain.StatusClosed)
+}
+
+// HandleReopenPR moves a CLOSED PR back to OPEN and assigns reviewers again.
+func (h *Handler) HandleReopenPR(c *gin.Context) {
+	h.transitionPR(c, domain.StatusClosed, domain.StatusOpen)
+}
+
+func (h *Handler) transitionPR(c *gin.Context, from, to domain.PRStatus) {
+	var req struct {
+		PRID string `json:"pull_request_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	pr, err := h.store.TransitionPR(c.Request.Context(), req.PRID, from, to)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr not found"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrPRMerged) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_MERGED", "message": "merged PR cannot change status"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrCapacityExhausted) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "CAPACITY_EXHAUSTED", "message": "every reviewer candidate is at their review limit"},
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "INVALID_TRANSITION", "message": "PR must be " + string(from) + " to become " + string(to)},
+			})
+			return
+		}
+
+		log.Printf("internal error transition (%s -> %s): %v", req.PRID, to, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": "internal server error"},
+		})
+		return
+	}
+
+	ar := make([]string, 0, len(pr.AssignedReviewers))
+	for _, r := range pr.AssignedReviewers {
+		ar = append(ar, string(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": gin.H{
+			"pull_request_id":    pr.ID,
+			"pull_request_name":  pr.Name,
+			"author_id":          pr.AuthorID,
+			"co_authors":         pr.CoAuthors,
+			"status":             pr.Status,
+			"assigned_reviewers": ar,
+			"fallback_reviewers": pr.FallbackReviewers,
+			"reviews":            pr.Reviews,
+			"labels":             pr.Labels,
+			"closedAt":           pr.ClosedAt,
+		},
+	})
+}
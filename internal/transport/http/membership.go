@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+const (
+	openReviewsKeep     = "KEEP"
+	openReviewsReassign = "REASSIGN"
+)
+
+func (h *Handler) HandleMoveTeam(c *gin.Context) {
+	var req struct {
+		UserID      string `json:"user_id"`
+		TeamName    string `json:"team_name"`
+		OpenReviews string `json:"open_reviews"` // KEEP (default) or REASSIGN
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	mode := strings.ToUpper(req.OpenReviews)
+	if mode == "" {
+		mode = openReviewsKeep
+	}
+	if mode != openReviewsKeep && mode != openReviewsReassign {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "open_reviews must be KEEP or REASSIGN"},
+		})
+		return
+	}
+
+	u, reassignments, err := h.store.MoveUserTeam(c.Request.Context(), req.UserID, req.TeamName, mode == openReviewsReassign)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "user or team not found"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrAlreadyInTeam) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "ALREADY_IN_TEAM", "message": "user already belongs to this team"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": gin.H{
+			"user_id":   u.ID,
+			"username":  u.Username,
+			"team_name": u.TeamName,
+			"is_active": u.IsActive,
+		},
+		"open_reviews":  mode,
+		"reassignments": reassignments,
+	})
+}
+
+func (h *Handler) HandleTeamHistory(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "user_id required"},
+		})
+		return
+	}
+
+	history, err := h.store.GetTeamHistory(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": userID,
+		"history": history,
+	})
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+type ownershipRuleDTO struct {
+	Pattern    string   `json:"pattern"`
+	OwnerTeams []string `json:"owner_teams"`
+	OwnerUsers []string `json:"owner_users"`
+}
+
+// HandleOwnershipAdd appends a CODEOWNERS-style rule to the team's list.
+func (h *Handler) HandleOwnershipAdd(c *gin.Context) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		ownershipRuleDTO
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	rule, err := domain.NewOwnershipRule(domain.TeamID(req.TeamName), req.Pattern, req.OwnerTeams, req.OwnerUsers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "pattern must be a single path glob and at least one owner is required"},
+		})
+		return
+	}
+
+	saved, err := h.store.CreateOwnershipRule(c.Request.Context(), rule)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team or owner not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rule": saved})
+}
+
+func (h *Handler) HandleOwnershipList(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "team_name required"},
+		})
+		return
+	}
+
+	rules, err := h.store.ListOwnershipRules(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_name": teamName,
+		"rules":     rules,
+	})
+}
+
+func (h *Handler) HandleOwnershipUpdate(c *gin.Context) {
+	var req struct {
+		RuleID int64 `json:"rule_id"`
+		ownershipRuleDTO
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	rule, err := domain.NewOwnershipRule("", req.Pattern, req.OwnerTeams, req.OwnerUsers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "pattern must be a single path glob and at least one owner is required"},
+		})
+		return
+	}
+
+	saved, err := h.store.UpdateOwnershipRule(c.Request.Context(), req.RuleID, rule)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "rule or owner not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rule": saved})
+}
+
+func (h *Handler) HandleOwnershipDelete(c *gin.Context) {
+	var req struct {
+		RuleID int64 `json:"rule_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	if err := h.store.DeleteOwnershipRule(c.Request.Context(), req.RuleID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "rule not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rule_id": req.RuleID})
+}
+
+// HandleOwnershipPreview shows which rule of the team owns each path and the
+// owners a PR touching those paths would need a reviewer from.
+func (h *Handler) HandleOwnershipPreview(c *gin.Context) {
+	var req struct {
+		TeamName string   `json:"team_name"`
+		Paths    []string `json:"paths"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	matches, owners, err := h.store.PreviewOwnership(c.Request.Context(), req.TeamName, req.Paths)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_name": req.TeamName,
+		"matches":   matches,
+		"owners":    owners,
+	})
+}
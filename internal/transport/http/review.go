@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+func (h *Handler) HandleReview(c *gin.Context) {
+	var req struct {
+		PRID    string `json:"pull_request_id"`
+		UserID  string `json:"user_id"`
+		Verdict string `json:"verdict"`
+		Body    string `json:"body"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	verdict := domain.Verdict(req.Verdict)
+	if err := verdict.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "verdict must be APPROVED, CHANGES_REQUESTED or COMMENTED"},
+		})
+		return
+	}
+
+	review, err := h.store.SubmitReview(c.Request.Context(), &domain.Review{
+		PullRequestID: req.PRID,
+		ReviewerID:    domain.UserID(req.UserID),
+		Verdict:       verdict,
+		Body:          req.Body,
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrReviewerNotAssigned) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "NOT_ASSIGNED", "message": "reviewer is not assigned to this PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrPRMerged) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_MERGED", "message": "cannot review merged PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrPRNotOpen) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_NOT_OPEN", "message": "cannot review draft or closed PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"pull_request_id": review.PullRequestID,
+		"review":          review,
+	})
+}
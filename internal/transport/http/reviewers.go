@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// HandleAddReviewer seats a chosen user on a free review slot.
+func (h *Handler) HandleAddReviewer(c *gin.Context) {
+	h.changeReviewer(c, "add reviewer", h.store.AddReviewer)
+}
+
+// HandleRemoveReviewer takes a reviewer off the PR without a replacement.
+func (h *Handler) HandleRemoveReviewer(c *gin.Context) {
+	h.changeReviewer(c, "remove reviewer", h.store.RemoveReviewer)
+}
+
+func (h *Handler) changeReviewer(c *gin.Context, action string, change func(ctx context.Context, prID, userID string) (*domain.PullRequest, error)) {
+	var req struct {
+		PRID   string `json:"pull_request_id"`
+		UserID string `json:"user_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	pr, err := change(c.Request.Context(), req.PRID, req.UserID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr or user not found"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrPRMerged) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_MERGED", "message": "cannot change reviewers on merged PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrPRNotOpen) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "PR_NOT_OPEN", "message": "cannot change reviewers on draft or closed PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrReviewerIsAuthor) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "REVIEWER_IS_AUTHOR", "message": "author cannot review own PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrReviewerInactive) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "REVIEWER_INACTIVE", "message": "reviewer is not active"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrAlreadyAssigned) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "ALREADY_ASSIGNED", "message": "reviewer is already assigned to this PR"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrReviewerExcluded) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "REVIEWER_EXCLUDED", "message": "the author excluded this reviewer"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrConflictOfInterest) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "CONFLICT_OF_INTEREST", "message": "reviewer has a conflict of interest with the author"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrTooManyReviewers) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "TOO_MANY_REVIEWERS", "message": "all review slots of the PR are taken"},
+			})
+			return
+		}
+		if errors.Is(err, store.ErrReviewerNotAssigned) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": gin.H{"code": "NOT_ASSIGNED", "message": "reviewer is not assigned to this PR"},
+			})
+			return
+		}
+
+		log.Printf("internal error %s (%s, %s): %v", action, req.PRID, req.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": "internal server error"},
+		})
+		return
+	}
+
+	ar := make([]string, 0, len(pr.AssignedReviewers))
+	for _, r := range pr.AssignedReviewers {
+		ar = append(ar, string(r))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": gin.H{
+			"pull_request_id":    pr.ID,
+			"pull_request_name":  pr.Name,
+			"author_id":          pr.AuthorID,
+			"co_authors":         pr.CoAuthors,
+			"status":             pr.Status,
+			"assigned_reviewers": ar,
+			"fallback_reviewers": pr.FallbackReviewers,
+			"reviews":            pr.Reviews,
+			"labels":             pr.Labels,
+		},
+	})
+}
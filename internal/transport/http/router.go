@@ -6,27 +6,73 @@ import (
 )
 
 type Handler struct {
-	store *store.Store
+	store      *store.Store
+	adminToken string
 }
 
-func NewRouter(s *store.Store) *gin.Engine {
-	h := &Handler{store: s}
+func NewRouter(s *store.Store, adminToken string) *gin.Engine {
+	h := &Handler{store: s, adminToken: adminToken}
 	r := gin.Default()
+	r.Use(actorMiddleware)
 
 	r.GET("/health", func(c *gin.Context) { c.JSON(200, gin.H{"status": "ok"}) })
 
 	// Teams
 	r.POST("/team/add", h.HandleTeamAdd)
 	r.GET("/team/get", h.HandleTeamGet)
+	r.POST("/team/update", h.HandleTeamUpdate)
+	r.POST("/team/setFallbacks", h.HandleTeamSetFallbacks)
+	r.POST("/team/rename", h.HandleTeamRename)
+	r.POST("/team/delete", h.HandleTeamDelete)
+	r.POST("/team/deactivateUsers", h.HandleTeamDeactivateUsers)
+	r.POST("/team/setSLA", h.HandleTeamSetSLA)
+	r.GET("/team/sla", h.HandleTeamSLA)
+	r.GET("/team/workload", h.HandleTeamWorkload)
+
+	// Ownership rules
+	r.POST("/ownership/add", h.HandleOwnershipAdd)
+	r.GET("/ownership/list", h.HandleOwnershipList)
+	r.POST("/ownership/update", h.HandleOwnershipUpdate)
+	r.POST("/ownership/delete", h.HandleOwnershipDelete)
+	r.POST("/ownership/preview", h.HandleOwnershipPreview)
 
 	// Users
 	r.POST("/users/setIsActive", h.HandleSetIsActive)
+	r.POST("/users/setMaxOpenReviews", h.HandleSetMaxOpenReviews)
 	r.GET("/users/getReview", h.HandleGetReview)
+	r.POST("/users/moveTeam", h.HandleMoveTeam)
+	r.GET("/users/teamHistory", h.HandleTeamHistory)
+	r.POST("/users/addAbsence", h.HandleAddAbsence)
+	r.GET("/users/absences", h.HandleListAbsences)
+	r.POST("/users/removeAbsence", h.HandleRemoveAbsence)
+	r.POST("/users/addSkill", h.HandleAddSkill)
+	r.POST("/users/removeSkill", h.HandleRemoveSkill)
+	r.GET("/users/skills", h.HandleListSkills)
+	r.POST("/users/addConflict", h.HandleAddConflict)
+	r.POST("/users/removeConflict", h.HandleRemoveConflict)
+	r.GET("/users/conflicts", h.HandleListConflicts)
 
 	// PRs
 	r.POST("/pullRequest/create", h.HandleCreatePR)
 	r.POST("/pullRequest/merge", h.HandleMergePR)
+	r.POST("/pullRequest/publish", h.HandlePublishPR)
+	r.POST("/pullRequest/close", h.HandleClosePR)
+	r.POST("/pullRequest/reopen", h.HandleReopenPR)
 	r.POST("/pullRequest/reassign", h.HandleReassign)
+	r.POST("/pullRequest/addReviewer", h.HandleAddReviewer)
+	r.POST("/pullRequest/removeReviewer", h.HandleRemoveReviewer)
+	r.POST("/pullRequest/review", h.HandleReview)
+	r.GET("/pullRequest/overdue", h.HandleOverduePRs)
+	r.POST("/pullRequest/addLabel", h.HandleAddLabel)
+	r.POST("/pullRequest/removeLabel", h.HandleRemoveLabel)
+	r.GET("/pullRequest/labels", h.HandleListLabels)
+	r.GET("/pullRequest/history", h.HandlePRHistory)
+
+	// Statistics
+	r.GET("/stats/assignments", h.HandleAssignmentStats)
+	r.GET("/stats/authors", h.HandleAuthorStats)
+	r.GET("/stats/mergeTime", h.HandleMergeTimeStats)
+	r.GET("/stats/reassignments", h.HandleReassignmentStats)
 
 	return r
 }
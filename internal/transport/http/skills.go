@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+func (h *Handler) HandleAddSkill(c *gin.Context) {
+	h.changeSkill(c, h.store.AddUserSkill)
+}
+
+func (h *Handler) HandleRemoveSkill(c *gin.Context) {
+	h.changeSkill(c, h.store.RemoveUserSkill)
+}
+
+func (h *Handler) changeSkill(c *gin.Context, change func(ctx context.Context, userID, skill string) ([]string, error)) {
+	var req struct {
+		UserID string `json:"user_id"`
+		Skill  string `json:"skill"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+	skill, err := domain.NormalizeTag(req.Skill)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "skill must be a non-empty word of up to 50 characters"},
+		})
+		return
+	}
+
+	skills, err := change(c.Request.Context(), req.UserID, skill)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "user or skill not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": req.UserID,
+		"skills":  skills,
+	})
+}
+
+func (h *Handler) HandleListSkills(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "user_id required"},
+		})
+		return
+	}
+
+	skills, err := h.store.ListUserSkills(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "user not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": userID,
+		"skills":  skills,
+	})
+}
+
+func (h *Handler) HandleAddLabel(c *gin.Context) {
+	h.changeLabel(c, h.store.AddPRLabel)
+}
+
+func (h *Handler) HandleRemoveLabel(c *gin.Context) {
+	h.changeLabel(c, h.store.RemovePRLabel)
+}
+
+func (h *Handler) changeLabel(c *gin.Context, change func(ctx context.Context, prID, label string) ([]string, error)) {
+	var req struct {
+		PRID  string `json:"pull_request_id"`
+		Label string `json:"label"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+	label, err := domain.NormalizeTag(req.Label)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "label must be a non-empty word of up to 50 characters"},
+		})
+		return
+	}
+
+	labels, err := change(c.Request.Context(), req.PRID, label)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr or label not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": req.PRID,
+		"labels":          labels,
+	})
+}
+
+func (h *Handler) HandleListLabels(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "pull_request_id required"},
+		})
+		return
+	}
+
+	labels, err := h.store.ListPRLabels(c.Request.Context(), prID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "pr not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pull_request_id": prID,
+		"labels":          labels,
+	})
+}
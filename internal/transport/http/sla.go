@@ -0,0 +1,128 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// HandleTeamSetSLA replaces the team's review SLA and working calendar.
+// Omitted fields fall back to the defaults.
+func (h *Handler) HandleTeamSetSLA(c *gin.Context) {
+	var req struct {
+		TeamName      string  `json:"team_name"`
+		Hours         *int    `json:"sla_hours"`
+		BusinessStart *string `json:"business_hours_start"`
+		BusinessEnd   *string `json:"business_hours_end"`
+		WeekendDays   *[]int  `json:"weekend_days"`
+		Timezone      *string `json:"timezone"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": err.Error()},
+		})
+		return
+	}
+
+	policy := domain.DefaultSLAPolicy(domain.TeamID(req.TeamName))
+	if req.Hours != nil {
+		policy.Hours = *req.Hours
+	}
+	if req.BusinessStart != nil {
+		policy.BusinessStart = *req.BusinessStart
+	}
+	if req.BusinessEnd != nil {
+		policy.BusinessEnd = *req.BusinessEnd
+	}
+	if req.WeekendDays != nil {
+		policy.WeekendDays = make([]time.Weekday, 0, len(*req.WeekendDays))
+		for _, d := range *req.WeekendDays {
+			policy.WeekendDays = append(policy.WeekendDays, time.Weekday(d))
+		}
+	}
+	if req.Timezone != nil {
+		policy.Timezone = *req.Timezone
+	}
+	if err := policy.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "sla_hours must be positive, business hours HH:MM with start before end, weekend_days 0-6 leaving a working day, timezone a valid IANA name"},
+		})
+		return
+	}
+
+	saved, err := h.store.SetTeamSLA(c.Request.Context(), policy)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sla": saved})
+}
+
+func (h *Handler) HandleTeamSLA(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "team_name required"},
+		})
+		return
+	}
+
+	policy, err := h.store.GetTeamSLA(c.Request.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sla": policy})
+}
+
+// HandleOverduePRs lists review assignments past their team's SLA, optionally
+// only for PRs authored in one team.
+func (h *Handler) HandleOverduePRs(c *gin.Context) {
+	teamName := c.Query("team_name")
+
+	overdue, err := h.store.OverdueReviews(c.Request.Context(), teamName, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	entries := make([]gin.H, 0, len(overdue))
+	for _, o := range overdue {
+		entries = append(entries, gin.H{
+			"pull_request_id":   o.PullRequestID,
+			"pull_request_name": o.PullRequestName,
+			"reviewer_id":       o.ReviewerID,
+			"team_name":         o.TeamName,
+			"assignedAt":        o.AssignedAt,
+			"dueAt":             o.DueAt,
+			"overdue_by":        o.OverdueBy.Round(time.Minute).String(),
+			"overdue_seconds":   int64(o.OverdueBy.Seconds()),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"overdue": entries})
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+// statsFilter reads team_name, from and to from the query. On failure the
+// error response is already written.
+func statsFilter(c *gin.Context) (domain.StatsFilter, bool) {
+	f, err := domain.NewStatsFilter(c.Query("team_name"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "from and to must be RFC 3339 timestamps or YYYY-MM-DD dates, from before to"},
+		})
+		return domain.StatsFilter{}, false
+	}
+	return f, true
+}
+
+func statsError(c *gin.Context, err error) {
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+	})
+}
+
+func (h *Handler) HandleAssignmentStats(c *gin.Context) {
+	f, ok := statsFilter(c)
+	if !ok {
+		return
+	}
+	stats, err := h.store.AssignmentStats(c.Request.Context(), f)
+	if err != nil {
+		statsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"assignments": stats})
+}
+
+func (h *Handler) HandleAuthorStats(c *gin.Context) {
+	f, ok := statsFilter(c)
+	if !ok {
+		return
+	}
+	stats, err := h.store.AuthorStats(c.Request.Context(), f)
+	if err != nil {
+		statsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"authors": stats})
+}
+
+func (h *Handler) HandleMergeTimeStats(c *gin.Context) {
+	f, ok := statsFilter(c)
+	if !ok {
+		return
+	}
+	stats, err := h.store.MergeTimeStats(c.Request.Context(), f)
+	if err != nil {
+		statsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"merge_time": stats})
+}
+
+func (h *Handler) HandleReassignmentStats(c *gin.Context) {
+	f, ok := statsFilter(c)
+	if !ok {
+		return
+	}
+	stats, total, err := h.store.ReassignmentStats(c.Request.Context(), f)
+	if err != nil {
+		statsError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"total": total, "users": stats})
+}
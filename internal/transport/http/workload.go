@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/n1ckerr0r/pull-requests-service/internal/store"
+)
+
+// HandleTeamWorkload reports each member's open and pending reviews and how
+// many reviews they completed recently.
+func (h *Handler) HandleTeamWorkload(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{"code": "BAD_REQUEST", "message": "team_name required"},
+		})
+		return
+	}
+
+	now := time.Now()
+	workload, err := h.store.TeamWorkload(c.Request.Context(), teamName, now)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "NOT_FOUND", "message": "team not found"},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": gin.H{"code": "INTERNAL", "message": err.Error()},
+		})
+		return
+	}
+
+	members := make([]gin.H, 0, len(workload))
+	for _, w := range workload {
+		members = append(members, gin.H{
+			"user_id":                    w.UserID,
+			"username":                   w.Username,
+			"is_active":                  w.IsActive,
+			"max_open_reviews":           w.MaxOpenReviews,
+			"open_reviews":               w.OpenReviews,
+			"pending_reviews":            w.PendingReviews,
+			"oldestPendingAt":            w.OldestPendingAt,
+			"oldest_pending_age_seconds": int64(w.OldestPendingAge(now).Seconds()),
+			"reviews_last_7_days":        w.ReviewedLast7,
+			"reviews_last_30_days":       w.ReviewedLast30,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team_name": teamName, "members": members})
+}
@@ -0,0 +1,8 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_reviewers SMALLINT NOT NULL DEFAULT 2
+        CHECK (required_reviewers BETWEEN 1 AND 10);
+
+-- slots are now bounded by teams.required_reviewers, which is enforced by the
+-- service; existing rows use slots 1 and 2 and stay valid
+ALTER TABLE pr_assignments DROP CONSTRAINT IF EXISTS pr_assignments_slot_check;
+ALTER TABLE pr_assignments ADD CONSTRAINT pr_assignments_slot_check CHECK (slot >= 1);
@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_approvals SMALLINT NOT NULL DEFAULT 1
+        CHECK (required_approvals BETWEEN 0 AND 10);
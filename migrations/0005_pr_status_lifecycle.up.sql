@@ -0,0 +1,4 @@
+ALTER TABLE prs DROP CONSTRAINT IF EXISTS prs_status_check;
+ALTER TABLE prs ADD CONSTRAINT prs_status_check CHECK (status IN ('DRAFT', 'OPEN', 'CLOSED', 'MERGED'));
+
+ALTER TABLE prs ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE NULL;
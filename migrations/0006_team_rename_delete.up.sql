@@ -0,0 +1,9 @@
+-- renaming a team carries users.team_name along; deleting a team no longer
+-- silently wipes its users and their PRs
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+UPDATE teams SET description = '' WHERE description IS NULL;
+ALTER TABLE teams ALTER COLUMN description SET DEFAULT '';
+ALTER TABLE teams ALTER COLUMN description SET NOT NULL;
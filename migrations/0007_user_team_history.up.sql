@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_team_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    from_team TEXT NULL,
+    to_team TEXT NOT NULL,
+    moved_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_team_history_user ON user_team_history (user_id, moved_at);
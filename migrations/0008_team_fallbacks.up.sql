@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    fallback_team TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    UNIQUE (team_name, position),
+    CHECK (team_name <> fallback_team)
+);
+
+-- set when the reviewer was drawn from one of the author team's fallbacks
+ALTER TABLE pr_assignments ADD COLUMN IF NOT EXISTS fallback_team TEXT NULL
+    REFERENCES teams(name) ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+    absence_id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    -- set once the background job has moved the user's open review slots
+    reassigned_at TIMESTAMP WITH TIME ZONE NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_period ON user_absences (user_id, starts_at, ends_at);
+CREATE INDEX IF NOT EXISTS idx_user_absences_pending ON user_absences (starts_at) WHERE reassigned_at IS NULL;
@@ -0,0 +1,2 @@
+-- NULL means the user has no limit
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
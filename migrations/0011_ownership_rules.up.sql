@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS ownership_rules (
+    rule_id BIGSERIAL PRIMARY KEY,
+    team_name TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    pattern TEXT NOT NULL,
+    owner_teams TEXT[] NOT NULL DEFAULT '{}',
+    owner_users TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ownership_rules_team ON ownership_rules (team_name, rule_id);
+
+CREATE TABLE IF NOT EXISTS pr_files (
+    pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, path)
+);
+
+-- set when the reviewer was seated to cover an owning team
+ALTER TABLE pr_assignments ADD COLUMN IF NOT EXISTS owner_team TEXT NULL
+    REFERENCES teams(name) ON UPDATE CASCADE ON DELETE SET NULL;
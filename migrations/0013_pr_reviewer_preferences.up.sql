@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS pr_reviewer_preferences (
+    pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('REQUIRED', 'EXCLUDED')),
+    position SMALLINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (pull_request_id, user_id)
+);
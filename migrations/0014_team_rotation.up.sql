@@ -0,0 +1,9 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS selection_strategy TEXT NOT NULL DEFAULT 'LEAST_LOADED'
+    CHECK (selection_strategy IN ('LEAST_LOADED', 'ROUND_ROBIN'));
+
+-- last member picked by a ROUND_ROBIN team; the next pick starts after them
+CREATE TABLE IF NOT EXISTS team_rotation_cursors (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    last_user_id TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
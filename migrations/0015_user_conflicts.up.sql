@@ -0,0 +1,11 @@
+-- conflicts are symmetric; each pair is stored once with user_a < user_b
+CREATE TABLE IF NOT EXISTS user_conflicts (
+    user_a TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_b TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (user_a, user_b),
+    CHECK (user_a < user_b)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_conflicts_user_b ON user_conflicts (user_b);
@@ -0,0 +1,14 @@
+-- every author of a PR; prs.author_id stays the primary author whose team
+-- staffs the review
+CREATE TABLE IF NOT EXISTS pr_authors (
+    pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (pull_request_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_authors_user ON pr_authors (user_id);
+
+INSERT INTO pr_authors (pull_request_id, user_id, is_primary)
+SELECT pull_request_id, author_id, true FROM prs
+ON CONFLICT DO NOTHING;
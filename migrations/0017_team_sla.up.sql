@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS team_sla_settings (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    sla_hours INT NOT NULL CHECK (sla_hours > 0),
+    business_start TIME NOT NULL,
+    business_end TIME NOT NULL,
+    weekend_days SMALLINT[] NOT NULL DEFAULT '{0,6}',
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    CHECK (business_start < business_end)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_assignments_assigned_at ON pr_assignments (assigned_at);
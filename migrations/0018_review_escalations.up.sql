@@ -0,0 +1,11 @@
+-- the lead is told about PRs whose reviews keep going stale
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS lead_user_id TEXT REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- how many times an overdue review on the PR was handed to someone else,
+-- and when the lead was notified instead
+CREATE TABLE IF NOT EXISTS pr_escalations (
+    pull_request_id TEXT PRIMARY KEY REFERENCES prs(pull_request_id) ON DELETE CASCADE,
+    hops INT NOT NULL DEFAULT 0,
+    lead_notified_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
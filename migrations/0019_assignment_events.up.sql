@@ -0,0 +1,13 @@
+-- reassignments overwrite the slot in pr_assignments, so every hand-over is
+-- also recorded here for reporting. pull_request_id is kept as plain text:
+-- the log outlives the PRs it describes.
+CREATE TABLE IF NOT EXISTS assignment_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL,
+    slot SMALLINT NOT NULL,
+    user_id TEXT NOT NULL,
+    previous_user_id TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_created ON assignment_events (created_at);
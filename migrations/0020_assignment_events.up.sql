@@ -0,0 +1,34 @@
+-- the reassignment log becomes an append-only audit trail of who was put on
+-- or taken off every review slot; the rows already logged are reassignments
+ALTER TABLE assignment_events
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'REASSIGNED'
+        CHECK (kind IN ('ASSIGNED', 'REASSIGNED', 'REMOVED', 'DEACTIVATION_REASSIGN')),
+    ADD COLUMN IF NOT EXISTS actor TEXT NULL,
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT 'recorded from reassignment log',
+    ALTER COLUMN previous_user_id DROP NOT NULL;
+
+ALTER TABLE assignment_events
+    ALTER COLUMN kind DROP DEFAULT,
+    ALTER COLUMN reason SET DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pr ON assignment_events (pull_request_id, event_id);
+
+-- current assignments that no logged reassignment explains are recorded as
+-- assigned when seated
+INSERT INTO assignment_events (pull_request_id, kind, slot, user_id, reason, created_at)
+SELECT a.pull_request_id, 'ASSIGNED', a.slot, a.user_id, 'recorded from existing assignment', COALESCE(a.assigned_at, now())
+FROM pr_assignments a
+WHERE NOT EXISTS (
+    SELECT 1 FROM assignment_events e
+    WHERE e.pull_request_id = a.pull_request_id AND e.slot = a.slot AND e.user_id = a.user_id
+);
+
+CREATE OR REPLACE FUNCTION assignment_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS assignment_events_append_only ON assignment_events;
+CREATE TRIGGER assignment_events_append_only BEFORE UPDATE OR DELETE ON assignment_events
+    FOR EACH ROW EXECUTE FUNCTION assignment_events_append_only();
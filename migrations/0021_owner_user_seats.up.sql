@@ -0,0 +1,4 @@
+-- set when the slot was seated for a user owning some of the changed files;
+-- it stays on the slot when someone else takes it over for them
+ALTER TABLE pr_assignments ADD COLUMN IF NOT EXISTS owner_user TEXT NULL
+    REFERENCES users(user_id) ON DELETE SET NULL;
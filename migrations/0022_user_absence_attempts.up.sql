@@ -0,0 +1,8 @@
+-- open review slots the absence job tried to hand on without finding a
+-- candidate; they are left to escalation instead of being tried every run
+CREATE TABLE IF NOT EXISTS user_absence_attempts (
+    absence_id BIGINT NOT NULL REFERENCES user_absences(absence_id) ON DELETE CASCADE,
+    pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id) ON DELETE CASCADE,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (absence_id, pull_request_id)
+);
@@ -0,0 +1,16 @@
+-- escalation is tracked per review slot, so a slot handed on by hand starts
+-- over without clearing the hops the PR's other slots have built up; what
+-- was recorded for a PR carries over to each of its current slots
+ALTER TABLE pr_escalations DROP CONSTRAINT IF EXISTS pr_escalations_pkey;
+ALTER TABLE pr_escalations ADD COLUMN IF NOT EXISTS slot SMALLINT;
+
+INSERT INTO pr_escalations (pull_request_id, slot, hops, lead_notified_at, updated_at)
+SELECT e.pull_request_id, a.slot, e.hops, e.lead_notified_at, e.updated_at
+FROM pr_escalations e
+JOIN pr_assignments a ON a.pull_request_id = e.pull_request_id
+WHERE e.slot IS NULL;
+
+DELETE FROM pr_escalations WHERE slot IS NULL;
+
+ALTER TABLE pr_escalations ALTER COLUMN slot SET NOT NULL;
+ALTER TABLE pr_escalations ADD PRIMARY KEY (pull_request_id, slot);
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestEscalationReassignsUntilHopsRunOut(t *testing.T) {
+	assigned := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	step, state := domain.NextEscalationStep(domain.EscalationState{}, assigned, 2)
+	if step != domain.EscalationReassign || state.Hops != 0 {
+		t.Fatalf("expected a first hop, got %v with %+v", step, state)
+	}
+
+	recorded := domain.EscalationState{Hops: 2, UpdatedAt: assigned}
+	if step, _ := domain.NextEscalationStep(recorded, assigned, 2); step != domain.EscalationNotifyLead {
+		t.Fatalf("expected the lead notified after 2 hops, got %v", step)
+	}
+
+	if step, _ := domain.NextEscalationStep(domain.EscalationState{}, assigned, 0); step != domain.EscalationNotifyLead {
+		t.Fatalf("expected the lead notified right away with no hops allowed, got %v", step)
+	}
+}
+
+func TestEscalationWaitsForNotifiedLead(t *testing.T) {
+	assigned := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	notified := domain.EscalationState{Hops: 2, LeadNotified: true, UpdatedAt: assigned.Add(time.Hour)}
+
+	if step, _ := domain.NextEscalationStep(notified, assigned, 2); step != domain.EscalationWait {
+		t.Fatalf("expected to wait for the lead, got %v", step)
+	}
+}
+
+func TestEscalationStartsOverWhenSlotChangesHands(t *testing.T) {
+	recordedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	notified := domain.EscalationState{Hops: 2, LeadNotified: true, UpdatedAt: recordedAt}
+
+	// the slot was handed over by someone else after the lead was told
+	step, state := domain.NextEscalationStep(notified, recordedAt.Add(time.Minute), 2)
+	if step != domain.EscalationReassign || state.Hops != 0 || state.LeadNotified {
+		t.Fatalf("expected a fresh start, got %v with %+v", step, state)
+	}
+
+	// escalation seats the new reviewer in the same transaction it records
+	// the hop in, so its own hand-overs keep counting
+	hopped := domain.EscalationState{Hops: 1, UpdatedAt: recordedAt}
+	if step, state := domain.NextEscalationStep(hopped, recordedAt, 2); step != domain.EscalationReassign || state.Hops != 1 {
+		t.Fatalf("expected the hop count kept, got %v with %+v", step, state)
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestMergePolicyRequiresApprovals(t *testing.T) {
+	policy := domain.MergePolicy{RequiredApprovals: 2}
+
+	unmet := policy.Evaluate([]domain.Review{
+		{ReviewerID: "u1", Verdict: domain.VerdictApproved},
+		{ReviewerID: "u2", Verdict: domain.VerdictCommented},
+	})
+	if len(unmet) != 1 {
+		t.Fatalf("expected 1 unmet rule, got %v", unmet)
+	}
+
+	unmet = policy.Evaluate([]domain.Review{
+		{ReviewerID: "u1", Verdict: domain.VerdictApproved},
+		{ReviewerID: "u2", Verdict: domain.VerdictApproved},
+	})
+	if len(unmet) != 0 {
+		t.Fatalf("expected policy to be satisfied, got %v", unmet)
+	}
+}
+
+func TestMergePolicyBlocksOnChangesRequested(t *testing.T) {
+	policy := domain.MergePolicy{RequiredApprovals: 1}
+
+	unmet := policy.Evaluate([]domain.Review{
+		{ReviewerID: "u1", Verdict: domain.VerdictApproved},
+		{ReviewerID: "u2", Verdict: domain.VerdictChangesRequested},
+	})
+	if len(unmet) != 1 {
+		t.Fatalf("expected 1 unmet rule, got %v", unmet)
+	}
+}
+
+func TestMergePolicyCommentKeepsChangesRequested(t *testing.T) {
+	policy := domain.MergePolicy{RequiredApprovals: 1}
+
+	// a comment after requesting changes does not lift the block
+	unmet := policy.Evaluate([]domain.Review{
+		{ReviewerID: "u1", Verdict: domain.VerdictApproved},
+		{ReviewerID: "u2", Verdict: domain.VerdictChangesRequested},
+		{ReviewerID: "u2", Verdict: domain.VerdictCommented},
+	})
+	if len(unmet) != 1 || unmet[0] != "changes requested by u2" {
+		t.Fatalf("expected the change request to still block, got %v", unmet)
+	}
+
+	// nor does one after approving take the approval back
+	unmet = policy.Evaluate([]domain.Review{
+		{ReviewerID: "u1", Verdict: domain.VerdictChangesRequested},
+		{ReviewerID: "u1", Verdict: domain.VerdictApproved},
+		{ReviewerID: "u1", Verdict: domain.VerdictCommented},
+	})
+	if len(unmet) != 0 {
+		t.Fatalf("expected the approval to stand, got %v", unmet)
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestOwnershipPatternMatching(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/store/store.go", true},
+		{"*.go", "README.md", false},
+		{"/docs/", "docs/api.md", true},
+		{"/docs/", "web/docs/api.md", false},
+		{"docs/", "docs", false},
+		{"migrations", "migrations/0001_init.up.sql", true},
+		{"internal/store/*.go", "internal/store/team.go", true},
+		{"internal/store/*.go", "internal/store/sub/team.go", false},
+		{"internal/**/*.go", "internal/store/sub/team.go", true},
+		{"**/Makefile", "build/Makefile", true},
+		{"cmd/?pp/", "cmd/app/main.go", true},
+	}
+
+	for _, tc := range cases {
+		rule, err := domain.NewOwnershipRule("backend", tc.pattern, []string{"backend"}, nil)
+		if err != nil {
+			t.Fatalf("pattern %q: %v", tc.pattern, err)
+		}
+		if got := rule.Matches(tc.path); got != tc.want {
+			t.Errorf("pattern %q on %q: expected %v, got %v", tc.pattern, tc.path, tc.want, got)
+		}
+	}
+}
+
+func TestOwnershipRuleRequiresPatternAndOwner(t *testing.T) {
+	if _, err := domain.NewOwnershipRule("backend", "", []string{"backend"}, nil); err == nil {
+		t.Fatalf("expected empty pattern to be rejected")
+	}
+	if _, err := domain.NewOwnershipRule("backend", "*.go", nil, nil); err == nil {
+		t.Fatalf("expected rule without owners to be rejected")
+	}
+}
+
+func TestMatchPathsLastRuleWins(t *testing.T) {
+	rules := []domain.OwnershipRule{
+		{ID: 1, Pattern: "*", OwnerTeams: []string{"backend"}},
+		{ID: 2, Pattern: "/docs/", OwnerUsers: []string{"writer"}},
+		{ID: 3, Pattern: "*.sql", OwnerTeams: []string{"dba"}},
+	}
+
+	matches, owners := domain.MatchPaths(rules, []string{"docs/guide.md", "migrations/0001.up.sql", "main.go"})
+
+	ids := make([]int64, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.Rule.ID)
+	}
+	if !reflect.DeepEqual(ids, []int64{2, 3, 1}) {
+		t.Fatalf("expected rules [2 3 1], got %v", ids)
+	}
+	if !reflect.DeepEqual(owners.Teams, []string{"dba", "backend"}) {
+		t.Fatalf("expected owner teams [dba backend], got %v", owners.Teams)
+	}
+	if !reflect.DeepEqual(owners.Users, []string{"writer"}) {
+		t.Fatalf("expected owner users [writer], got %v", owners.Users)
+	}
+}
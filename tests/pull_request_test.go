@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestPRStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from, to domain.PRStatus
+		allowed  bool
+	}{
+		{domain.StatusDraft, domain.StatusOpen, true},
+		{domain.StatusOpen, domain.StatusClosed, true},
+		{domain.StatusClosed, domain.StatusOpen, true},
+		{domain.StatusOpen, domain.StatusMerged, true},
+		{domain.StatusDraft, domain.StatusMerged, false},
+		{domain.StatusClosed, domain.StatusMerged, false},
+		{domain.StatusMerged, domain.StatusOpen, false},
+		{domain.StatusOpen, domain.StatusDraft, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.from.CanTransitionTo(tc.to); got != tc.allowed {
+			t.Errorf("%s -> %s: expected allowed=%v, got %v", tc.from, tc.to, tc.allowed, got)
+		}
+	}
+}
+
+func TestValidateReviewerChoice(t *testing.T) {
+	cases := []struct {
+		name     string
+		pinned   []domain.UserID
+		excluded []domain.UserID
+		ok       bool
+	}{
+		{"none", nil, nil, true},
+		{"distinct", []domain.UserID{"u2"}, []domain.UserID{"u3"}, true},
+		{"author pinned", []domain.UserID{"u1"}, nil, false},
+		{"author excluded", nil, []domain.UserID{"u1"}, false},
+		{"co-author pinned", []domain.UserID{"u4"}, nil, false},
+		{"pinned and excluded", []domain.UserID{"u2"}, []domain.UserID{"u2"}, false},
+		{"pinned twice", []domain.UserID{"u2", "u2"}, nil, false},
+	}
+
+	for _, tc := range cases {
+		err := domain.ValidateReviewerChoice([]domain.UserID{"u1", "u4"}, tc.pinned, tc.excluded)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: expected ok=%v, got %v", tc.name, tc.ok, err)
+		}
+	}
+}
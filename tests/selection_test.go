@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/selection"
+)
+
+func TestLeastLoadedPrefersFewestOpenReviews(t *testing.T) {
+	s := selection.NewLeastLoaded(1)
+
+	candidates := []selection.Candidate{
+		{UserID: "u1", OpenReviews: 3},
+		{UserID: "u2", OpenReviews: 0},
+		{UserID: "u3", OpenReviews: 1},
+		{UserID: "u4", OpenReviews: 5},
+	}
+
+	picked := s.Pick(candidates, 2)
+	if !reflect.DeepEqual(picked, []string{"u2", "u3"}) {
+		t.Fatalf("expected [u2 u3], got %v", picked)
+	}
+}
+
+func TestLeastLoadedIsDeterministicForSeed(t *testing.T) {
+	candidates := []selection.Candidate{
+		{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}, {UserID: "u5"},
+	}
+	reversed := []selection.Candidate{
+		{UserID: "u5"}, {UserID: "u4"}, {UserID: "u3"}, {UserID: "u2"}, {UserID: "u1"},
+	}
+
+	first := selection.NewLeastLoaded(42).Pick(candidates, 2)
+	second := selection.NewLeastLoaded(42).Pick(reversed, 2)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("expected same pick for same seed, got %v and %v", first, second)
+	}
+}
+
+func TestLeastLoadedFewerCandidatesThanSlots(t *testing.T) {
+	s := selection.NewLeastLoaded(7)
+
+	if picked := s.Pick(nil, 2); len(picked) != 0 {
+		t.Fatalf("expected no reviewers, got %v", picked)
+	}
+
+	picked := s.Pick([]selection.Candidate{{UserID: "only"}}, 2)
+	if !reflect.DeepEqual(picked, []string{"only"}) {
+		t.Fatalf("expected [only], got %v", picked)
+	}
+}
+
+func TestAvailableSkipsSaturatedCandidates(t *testing.T) {
+	two, five := 2, 5
+	candidates := []selection.Candidate{
+		{UserID: "junior", OpenReviews: 2, MaxOpenReviews: &two},
+		{UserID: "senior", OpenReviews: 4, MaxOpenReviews: &five},
+		{UserID: "unlimited", OpenReviews: 9},
+	}
+
+	free, saturated := selection.Available(candidates)
+	if saturated != 1 {
+		t.Fatalf("expected 1 saturated candidate, got %d", saturated)
+	}
+	if len(free) != 2 || free[0].UserID != "senior" || free[1].UserID != "unlimited" {
+		t.Fatalf("expected [senior unlimited], got %v", free)
+	}
+}
+
+func TestPickSkilledPrefersMatchingSkills(t *testing.T) {
+	s := selection.NewLeastLoaded(3)
+
+	candidates := []selection.Candidate{
+		{UserID: "idle", OpenReviews: 0},
+		{UserID: "gopher", OpenReviews: 4, SkillMatches: 1},
+		{UserID: "busy", OpenReviews: 6},
+	}
+
+	picked := selection.PickSkilled(s, candidates, 2)
+	if !reflect.DeepEqual(picked, []string{"gopher", "idle"}) {
+		t.Fatalf("expected [gopher idle], got %v", picked)
+	}
+}
+
+func TestRoundRobinStartsAfterCursor(t *testing.T) {
+	candidates := []selection.Candidate{
+		{UserID: "u3"}, {UserID: "u1", OpenReviews: 9}, {UserID: "u4"}, {UserID: "u2"},
+	}
+
+	if picked := selection.NewRoundRobin("").Pick(candidates, 2); !reflect.DeepEqual(picked, []string{"u1", "u2"}) {
+		t.Fatalf("expected [u1 u2], got %v", picked)
+	}
+	if picked := selection.NewRoundRobin("u2").Pick(candidates, 2); !reflect.DeepEqual(picked, []string{"u3", "u4"}) {
+		t.Fatalf("expected [u3 u4], got %v", picked)
+	}
+	// the cursor wraps around, and a cursor user who left the pool is skipped
+	if picked := selection.NewRoundRobin("u35").Pick(candidates, 3); !reflect.DeepEqual(picked, []string{"u4", "u1", "u2"}) {
+		t.Fatalf("expected [u4 u1 u2], got %v", picked)
+	}
+}
+
+func TestRoundRobinPrefersSkilled(t *testing.T) {
+	candidates := []selection.Candidate{
+		{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3", SkillMatches: 1}, {UserID: "u4", SkillMatches: 2},
+	}
+
+	// the rotation runs among the skilled first, then among the rest
+	if picked := selection.PickSkilled(selection.NewRoundRobin("u3"), candidates, 3); !reflect.DeepEqual(picked, []string{"u4", "u3", "u1"}) {
+		t.Fatalf("expected [u4 u3 u1], got %v", picked)
+	}
+	if picked := selection.PickSkilled(selection.NewRoundRobin("u1"), candidates, 1); !reflect.DeepEqual(picked, []string{"u3"}) {
+		t.Fatalf("expected [u3], got %v", picked)
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestSLADueAtSkipsNightsAndWeekends(t *testing.T) {
+	p := domain.DefaultSLAPolicy("backend")
+	p.Hours = 12
+
+	// Friday 15:00: 3h left on Friday, Monday 09:00 + 9h runs to 18:00
+	assigned := time.Date(2024, time.March, 1, 15, 0, 0, 0, time.UTC)
+	want := time.Date(2024, time.March, 4, 18, 0, 0, 0, time.UTC)
+	if got := p.DueAt(assigned); !got.Equal(want) {
+		t.Fatalf("expected due at %v, got %v", want, got)
+	}
+
+	// assigned on Saturday the clock starts on Monday morning
+	saturday := time.Date(2024, time.March, 2, 11, 0, 0, 0, time.UTC)
+	want = time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	if got := p.DueAt(saturday); !got.Equal(want) {
+		t.Fatalf("expected due at %v, got %v", want, got)
+	}
+}
+
+func TestSLAWorkingTime(t *testing.T) {
+	p := domain.DefaultSLAPolicy("backend")
+
+	from := time.Date(2024, time.March, 1, 17, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 4, 10, 30, 0, 0, time.UTC)
+	if got := p.WorkingTime(from, to); got != 150*time.Minute {
+		t.Fatalf("expected 2h30m of working time, got %v", got)
+	}
+	if got := p.WorkingTime(to, from); got != 0 {
+		t.Fatalf("expected no working time backwards, got %v", got)
+	}
+}
+
+func TestSLAPolicyValidate(t *testing.T) {
+	p := domain.DefaultSLAPolicy("backend")
+	if err := p.Validate(); err != nil {
+		t.Fatalf("expected default policy to be valid, got %v", err)
+	}
+
+	bad := []func(p *domain.SLAPolicy){
+		func(p *domain.SLAPolicy) { p.Hours = 0 },
+		func(p *domain.SLAPolicy) { p.BusinessStart, p.BusinessEnd = "18:00", "09:00" },
+		func(p *domain.SLAPolicy) { p.BusinessEnd = "25:00" },
+		func(p *domain.SLAPolicy) { p.Timezone = "Mars/Olympus" },
+		func(p *domain.SLAPolicy) {
+			p.WeekendDays = []time.Weekday{0, 1, 2, 3, 4, 5, 6}
+		},
+	}
+	for i, mutate := range bad {
+		p := domain.DefaultSLAPolicy("backend")
+		mutate(p)
+		if err := p.Validate(); err == nil {
+			t.Errorf("case %d: expected invalid policy", i)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/n1ckerr0r/pull-requests-service/internal/domain"
+)
+
+func TestStatsFilterIncludesWholeToDate(t *testing.T) {
+	f, err := domain.NewStatsFilter("backend", "2024-03-01", "2024-03-31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC); !f.From.Equal(want) {
+		t.Fatalf("expected from %v, got %v", want, f.From)
+	}
+	if want := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC); !f.To.Equal(want) {
+		t.Fatalf("expected to %v, got %v", want, f.To)
+	}
+
+	f, err = domain.NewStatsFilter("", "", "2024-03-31T12:00:00Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.From != nil || !f.To.Equal(time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected open start and exact end, got %v - %v", f.From, f.To)
+	}
+}
+
+func TestStatsFilterRejectsBadRange(t *testing.T) {
+	for _, tc := range [][2]string{
+		{"yesterday", ""},
+		{"2024-03-31", "2024-03-01"},
+		{"2024-03-01T00:00:00Z", "2024-03-01T00:00:00Z"},
+	} {
+		if _, err := domain.NewStatsFilter("", tc[0], tc[1]); !errors.Is(err, domain.ErrInvalidStatsRange) {
+			t.Fatalf("expected ErrInvalidStatsRange for %q - %q, got %v", tc[0], tc[1], err)
+		}
+	}
+}